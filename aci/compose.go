@@ -157,6 +157,9 @@ func (cs *aciComposeService) Down(ctx context.Context, projectName string, optio
 }
 
 func (cs *aciComposeService) Ps(ctx context.Context, projectName string, options compose.PsOptions) ([]compose.ContainerSummary, error) {
+	if len(options.Status) > 0 || len(options.Health) > 0 || len(options.Labels) > 0 {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--filter option is not supported on ACI")
+	}
 	groupsClient, err := login.NewContainerGroupsClient(cs.ctx.SubscriptionID)
 	if err != nil {
 		return nil, err
@@ -265,3 +268,19 @@ func (cs *aciComposeService) Port(ctx context.Context, project string, service s
 func (cs *aciComposeService) Images(ctx context.Context, projectName string, options compose.ImagesOptions) ([]compose.ImageSummary, error) {
 	return nil, errdefs.ErrNotImplemented
 }
+
+func (cs *aciComposeService) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	return 0, errdefs.ErrNotImplemented
+}
@@ -123,3 +123,19 @@ func (c *composeService) Port(ctx context.Context, project string, service strin
 func (c *composeService) Images(ctx context.Context, projectName string, options compose.ImagesOptions) ([]compose.ImageSummary, error) {
 	return nil, errdefs.ErrNotImplemented
 }
+
+func (c *composeService) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (c *composeService) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (c *composeService) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (c *composeService) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	return 0, errdefs.ErrNotImplemented
+}
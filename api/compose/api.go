@@ -74,8 +74,16 @@ type Service interface {
 	Events(ctx context.Context, project string, options EventsOptions) error
 	// Port executes the equivalent to a `compose port`
 	Port(ctx context.Context, project string, service string, port int, options PortOptions) (string, int, error)
+	// PortForward forwards local ports to service containers until ctx is done
+	PortForward(ctx context.Context, project *types.Project, options PortForwardOptions) error
 	// Images executes the equivalent of a `compose images`
 	Images(ctx context.Context, projectName string, options ImagesOptions) ([]ImageSummary, error)
+	// BackupVolumes archives project named volumes
+	BackupVolumes(ctx context.Context, projectName string, options BackupVolumesOptions) error
+	// RestoreVolumes recreates project named volumes from a backup
+	RestoreVolumes(ctx context.Context, projectName string, options RestoreVolumesOptions) error
+	// Wait blocks until service containers exit, and returns their exit code
+	Wait(ctx context.Context, projectName string, options WaitOptions) (int, error)
 }
 
 // BuildOptions group options of the Build API
@@ -108,6 +116,10 @@ type CreateOptions struct {
 	Timeout *time.Duration
 	// QuietPull makes the pulling process quiet
 	QuietPull bool
+	// PullParallelism limits the number of images pulled concurrently, 0 means no limit
+	PullParallelism int
+	// RecreateNetworks recreates existing networks which configuration doesn't match the model
+	RecreateNetworks bool
 }
 
 // StartOptions group options of the Start API
@@ -164,17 +176,38 @@ type ConvertOptions struct {
 	Format string
 	// Output defines the path to save the application model
 	Output string
+	// FromRunning rebuilds application model from deployed resources, not from compose files
+	FromRunning bool
+	// Services restricts application model rebuilt by FromRunning to these services
+	Services []string
 }
 
 // PushOptions group options of the Push API
 type PushOptions struct {
 	IgnoreFailures bool
+	// Tags are additional tags to apply to service images and push
+	Tags []string
+	// Parallelism limits the number of concurrent pushes to a registry, 0 means no limit
+	Parallelism int
+	// Summary is called, if set, with the result of each image push
+	Summary func(summary PushSummary)
+}
+
+// PushSummary holds the result of an image push
+type PushSummary struct {
+	Service string
+	Image   string
+	Digest  string
+	// Skipped is set when the image was already up to date on the registry
+	Skipped bool
 }
 
 // PullOptions group options of the Pull API
 type PullOptions struct {
 	Quiet          bool
 	IgnoreFailures bool
+	// Parallelism limits the number of images pulled concurrently, 0 means no limit
+	Parallelism int
 }
 
 // ImagesOptions group options of the Images API
@@ -182,6 +215,24 @@ type ImagesOptions struct {
 	Services []string
 }
 
+// BackupVolumesOptions group options of the BackupVolumes API
+type BackupVolumesOptions struct {
+	// Output is the directory to write volume archives and manifest to
+	Output string
+	// Volumes restricts backup to these compose volumes
+	Volumes []string
+}
+
+// RestoreVolumesOptions group options of the RestoreVolumes API
+type RestoreVolumesOptions struct {
+	// Input is the directory a backup has been written to
+	Input string
+	// Volumes restricts restore to these compose volumes
+	Volumes []string
+	// Force don't ask to confirm existing volumes replacement
+	Force bool
+}
+
 // KillOptions group options of the Kill API
 type KillOptions struct {
 	// Signal to send to containers
@@ -219,12 +270,23 @@ type RunOptions struct {
 	UseNetworkAliases bool
 	// used by exec
 	Index int
+	// All runs exec in every replica of Services, or Service if not set
+	All      bool
+	Services []string
+	// Consumer collects output of commands ran with All, prefixed by container name
+	Consumer LogConsumer
 }
 
 // EventsOptions group options of the Events API
 type EventsOptions struct {
 	Services []string
 	Consumer func(event Event) error
+	// Since replays events since a timestamp (RFC3339) or relative time (i.e. 42m)
+	Since string
+	// Until stops streaming events after a timestamp (RFC3339) or relative time (i.e. 42m)
+	Until string
+	// Types only selects events with these statuses, i.e. die, oom, health_status or restart
+	Types []string
 }
 
 // Event is a container runtime event served by Events API
@@ -234,14 +296,34 @@ type Event struct {
 	Container  string
 	Status     string
 	Attributes map[string]string
+	// ExitCode is set for `die` events
+	ExitCode int
 }
 
+const (
+	// EventDie is the status of events sent when a container exits
+	EventDie = "die"
+	// EventHealthStatus is the status of events sent when a container health state changes. Attributes hold
+	// the new (`health_status`) and previous (`previous_health_status`) health state
+	EventHealthStatus = "health_status"
+)
+
 // PortOptions group options of the Port API
 type PortOptions struct {
 	Protocol string
 	Index    int
 }
 
+// PortForwardOptions group options of the PortForward API
+type PortForwardOptions struct {
+	// Services passed in the command line to forward ports for
+	Services []string
+	// Address local ports are bound to
+	Address string
+	// Writer receives forwarding status messages
+	Writer io.Writer
+}
+
 func (e Event) String() string {
 	t := e.Timestamp.Format("2006-01-02 15:04:05.000000")
 	var attr []string
@@ -261,6 +343,12 @@ type ListOptions struct {
 type PsOptions struct {
 	All      bool
 	Services []string
+	// Status only selects containers in one of these states, i.e. running or exited
+	Status []string
+	// Health only selects containers in one of these health states: starting, healthy, unhealthy or none
+	Health []string
+	// Labels only selects containers with all these labels, set as `key` or `key=value`
+	Labels []string
 }
 
 // CopyOptions group options of the cp API
@@ -285,12 +373,16 @@ type PortPublisher struct {
 type ContainerSummary struct {
 	ID         string
 	Name       string
+	Image      string
+	Command    string
 	Project    string
 	Service    string
+	Created    int64
 	State      string
 	Health     string
 	ExitCode   int
 	Publishers []PortPublisher
+	Networks   []string
 }
 
 // ContainerProcSummary holds container processes top data
@@ -326,6 +418,20 @@ type LogOptions struct {
 	Tail       string
 	Follow     bool
 	Timestamps bool
+	// Since only shows logs after a timestamp (RFC3339) or relative time (i.e. 42m)
+	Since string
+	// Until only shows logs before a timestamp (RFC3339) or relative time (i.e. 42m)
+	Until string
+	// Grep only shows log lines matching a regular expression
+	Grep string
+}
+
+// WaitOptions group options of the Wait API
+type WaitOptions struct {
+	// Services passed in the command line to wait for
+	Services []string
+	// All waits for all containers to exit and returns the worst exit code, rather than the first one
+	All bool
 }
 
 // PauseOptions group options of the Pause API
@@ -369,6 +475,8 @@ type Stack struct {
 // LogConsumer is a callback to process log messages from services
 type LogConsumer interface {
 	Log(service, container, message string)
+	// Err process a message written by container on stderr
+	Err(container, service, message string)
 	Status(container, msg string)
 	Register(container string)
 }
@@ -396,4 +504,6 @@ const (
 	ContainerEventExit
 	// UserCancel user cancelled compose up, we are stopping containers
 	UserCancel
+	// ContainerEventErr is a ContainerEvent of type log, for a line written on stderr. Line is set
+	ContainerEventErr
 )
@@ -49,7 +49,11 @@ type ServiceProxy struct {
 	TopFn                func(ctx context.Context, projectName string, services []string) ([]ContainerProcSummary, error)
 	EventsFn             func(ctx context.Context, project string, options EventsOptions) error
 	PortFn               func(ctx context.Context, project string, service string, port int, options PortOptions) (string, int, error)
+	PortForwardFn        func(ctx context.Context, project *types.Project, options PortForwardOptions) error
 	ImagesFn             func(ctx context.Context, projectName string, options ImagesOptions) ([]ImageSummary, error)
+	BackupVolumesFn      func(ctx context.Context, projectName string, options BackupVolumesOptions) error
+	RestoreVolumesFn     func(ctx context.Context, projectName string, options RestoreVolumesOptions) error
+	WaitFn               func(ctx context.Context, projectName string, options WaitOptions) (int, error)
 	interceptors         []Interceptor
 }
 
@@ -88,7 +92,11 @@ func (s *ServiceProxy) WithService(service Service) *ServiceProxy {
 	s.TopFn = service.Top
 	s.EventsFn = service.Events
 	s.PortFn = service.Port
+	s.PortForwardFn = service.PortForward
 	s.ImagesFn = service.Images
+	s.BackupVolumesFn = service.BackupVolumes
+	s.RestoreVolumesFn = service.RestoreVolumes
+	s.WaitFn = service.Wait
 	return s
 }
 
@@ -324,6 +332,17 @@ func (s *ServiceProxy) Port(ctx context.Context, project string, service string,
 	return s.PortFn(ctx, project, service, port, options)
 }
 
+//PortForward implements Service interface
+func (s *ServiceProxy) PortForward(ctx context.Context, project *types.Project, options PortForwardOptions) error {
+	if s.PortForwardFn == nil {
+		return errdefs.ErrNotImplemented
+	}
+	for _, i := range s.interceptors {
+		i(ctx, project)
+	}
+	return s.PortForwardFn(ctx, project, options)
+}
+
 //Images implements Service interface
 func (s *ServiceProxy) Images(ctx context.Context, project string, options ImagesOptions) ([]ImageSummary, error) {
 	if s.ImagesFn == nil {
@@ -331,3 +350,27 @@ func (s *ServiceProxy) Images(ctx context.Context, project string, options Image
 	}
 	return s.ImagesFn(ctx, project, options)
 }
+
+//BackupVolumes implements Service interface
+func (s *ServiceProxy) BackupVolumes(ctx context.Context, project string, options BackupVolumesOptions) error {
+	if s.BackupVolumesFn == nil {
+		return errdefs.ErrNotImplemented
+	}
+	return s.BackupVolumesFn(ctx, project, options)
+}
+
+//RestoreVolumes implements Service interface
+func (s *ServiceProxy) RestoreVolumes(ctx context.Context, project string, options RestoreVolumesOptions) error {
+	if s.RestoreVolumesFn == nil {
+		return errdefs.ErrNotImplemented
+	}
+	return s.RestoreVolumesFn(ctx, project, options)
+}
+
+//Wait implements Service interface
+func (s *ServiceProxy) Wait(ctx context.Context, project string, options WaitOptions) (int, error) {
+	if s.WaitFn == nil {
+		return 0, errdefs.ErrNotImplemented
+	}
+	return s.WaitFn(ctx, project, options)
+}
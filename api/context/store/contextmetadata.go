@@ -60,6 +60,7 @@ type KubeContext struct {
 	ContextName     string `json:",omitempty"`
 	KubeconfigPath  string `json:",omitempty"`
 	FromEnvironment bool
+	Registry        string `json:",omitempty"`
 }
 
 // AwsContext is the context for the ecs plugin
@@ -266,6 +266,8 @@ func RootCommand(contextType string, backend compose.Service) *cobra.Command {
 		eventsCommand(&opts, backend),
 		portCommand(&opts, backend),
 		imagesCommand(&opts, backend),
+		waitCommand(&opts, backend),
+		doCommand(&opts, backend),
 		versionCommand(),
 	)
 
@@ -276,6 +278,16 @@ func RootCommand(contextType string, backend compose.Service) *cobra.Command {
 			pullCommand(&opts, backend),
 			createCommand(&opts, backend),
 			copyCommand(&opts, backend),
+			volumesCommand(&opts, backend),
+		)
+	}
+	if contextType == store.KubeContextType {
+		command.AddCommand(
+			buildCommand(&opts, backend),
+			pushCommand(&opts, backend),
+			pullCommand(&opts, backend),
+			copyCommand(&opts, backend),
+			portForwardCommand(&opts, backend),
 		)
 	}
 	command.Flags().SetInterspersed(false)
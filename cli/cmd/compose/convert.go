@@ -27,6 +27,7 @@ import (
 
 	"github.com/cnabio/cnab-to-oci/remotes"
 	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/types"
 	"github.com/distribution/distribution/v3/reference"
 	cliconfig "github.com/docker/cli/cli/config"
 	"github.com/opencontainers/go-digest"
@@ -47,6 +48,7 @@ type convertOptions struct {
 	volumes       bool
 	profiles      bool
 	hash          string
+	fromRunning   bool
 }
 
 var addFlagsFuncs []func(cmd *cobra.Command, opts *convertOptions)
@@ -96,6 +98,7 @@ func convertCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	flags.BoolVar(&opts.volumes, "volumes", false, "Print the volume names, one per line.")
 	flags.BoolVar(&opts.profiles, "profiles", false, "Print the profile names, one per line.")
 	flags.StringVar(&opts.hash, "hash", "", "Print the service config hash, one per line.")
+	flags.BoolVar(&opts.fromRunning, "from-running", false, "Rebuild the compose model from deployed containers, networks and volumes.")
 
 	// add flags for hidden backends
 	for _, f := range addFlagsFuncs {
@@ -105,6 +108,10 @@ func convertCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 }
 
 func runConvert(ctx context.Context, backend compose.Service, opts convertOptions, services []string) error {
+	if opts.fromRunning {
+		return runConvertFromRunning(ctx, backend, opts, services)
+	}
+
 	var json []byte
 	project, err := opts.toProject(services, cli.WithInterpolation(!opts.noInterpolate))
 	if err != nil {
@@ -131,7 +138,28 @@ func runConvert(ctx context.Context, backend compose.Service, opts convertOption
 	if err != nil {
 		return err
 	}
+	return printConvert(opts, json)
+}
+
+func runConvertFromRunning(ctx context.Context, backend compose.Service, opts convertOptions, services []string) error {
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+
+	json, err := backend.Convert(ctx, &types.Project{Name: projectName}, compose.ConvertOptions{
+		Format:      opts.Format,
+		Output:      opts.Output,
+		FromRunning: true,
+		Services:    services,
+	})
+	if err != nil {
+		return err
+	}
+	return printConvert(opts, json)
+}
 
+func printConvert(opts convertOptions, json []byte) error {
 	if opts.quiet {
 		return nil
 	}
@@ -144,7 +172,7 @@ func runConvert(ctx context.Context, backend compose.Service, opts convertOption
 		}
 		out = bufio.NewWriter(file)
 	}
-	_, err = fmt.Fprint(out, string(json))
+	_, err := fmt.Fprint(out, string(json))
 	return err
 }
 
@@ -43,8 +43,9 @@ func copyCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	}
 	copyCmd := &cobra.Command{
 		Use: `cp [OPTIONS] SERVICE:SRC_PATH DEST_PATH|-
-	docker compose cp [OPTIONS] SRC_PATH|- SERVICE:DEST_PATH`,
-		Short: "Copy files/folders between a service container and the local filesystem",
+	docker compose cp [OPTIONS] SRC_PATH|- SERVICE:DEST_PATH
+	docker compose cp [OPTIONS] SERVICE:SRC_PATH SERVICE:DEST_PATH`,
+		Short: "Copy files/folders between a service container and the local filesystem, or another service container",
 		Args:  cli.ExactArgs(2),
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
 			if args[0] == "" {
@@ -64,7 +65,7 @@ func copyCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 
 	flags := copyCmd.Flags()
 	flags.IntVar(&opts.index, "index", 1, "Index of the container if there are multiple instances of a service [default: 1].")
-	flags.BoolVar(&opts.all, "all", false, "Copy to all the containers of the service.")
+	flags.BoolVar(&opts.all, "all", false, "Copy to all the containers of the service, or from all of them into per-container sub-directories of DEST_PATH.")
 	flags.BoolVarP(&opts.followLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
 	flags.BoolVarP(&opts.copyUIDGID, "archive", "a", false, "Archive mode (copy all uid/gid information)")
 
@@ -38,6 +38,8 @@ type createOptions struct {
 	timeChanged   bool
 	timeout       int
 	quietPull     bool
+	pullParallel  int
+	recreateNets  bool
 }
 
 func createCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -62,6 +64,7 @@ func createCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 				Inherit:              !opts.noInherit,
 				Timeout:              opts.GetTimeout(),
 				QuietPull:            false,
+				RecreateNetworks:     opts.recreateNets,
 			})
 		}),
 	}
@@ -70,6 +73,7 @@ func createCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	flags.BoolVar(&opts.noBuild, "no-build", false, "Don't build an image, even if it's missing.")
 	flags.BoolVar(&opts.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed.")
 	flags.BoolVar(&opts.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
+	flags.BoolVar(&opts.recreateNets, "recreate-networks", false, "Recreate networks which configuration changed, detaching and reattaching containers.")
 	return cmd
 }
 
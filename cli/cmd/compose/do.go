@@ -0,0 +1,211 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/mattn/go-shellwords"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// commandsExtension is the top-level extension declaring project custom commands
+const commandsExtension = "x-commands"
+
+// projectCommand is a custom command declared by the compose file
+type projectCommand struct {
+	Description string      `json:"description,omitempty"`
+	Service     string      `json:"service"`
+	Command     interface{} `json:"command,omitempty"`
+	Environment interface{} `json:"environment,omitempty"`
+	WorkingDir  string      `json:"working_dir,omitempty"`
+	User        string      `json:"user,omitempty"`
+	// Exec runs command in a running service container, rather than a one-off container
+	Exec bool `json:"exec,omitempty"`
+	// Deps starts service dependencies before a one-off container is ran, default to true
+	Deps *bool `json:"deps,omitempty"`
+}
+
+func doCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "do COMMAND [ARGS...]",
+		Short: "Run a custom command declared by the compose file x-commands extension",
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return listProjectCommands(p, os.Stdout)
+			}
+			return runDo(ctx, backend, p, args[0], args[1:])
+		}),
+	}
+	defaultHelp := cmd.HelpFunc()
+	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		defaultHelp(c, args)
+		// project might not be loadable from current directory, which is fine to display help
+		_ = listProjectCommands(p, c.OutOrStdout())
+	})
+	cmd.Flags().SetInterspersed(false)
+	return cmd
+}
+
+func listProjectCommands(p *projectOptions, out io.Writer) error {
+	project, err := p.toProject(nil)
+	if err != nil {
+		return err
+	}
+	commands, err := getProjectCommands(project)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(out, "\nProject commands:")
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", name, commands[name].Description)
+	}
+	return w.Flush()
+}
+
+func getProjectCommands(project *types.Project) (map[string]projectCommand, error) {
+	commands := map[string]projectCommand{}
+	x, ok := project.Extensions[commandsExtension]
+	if !ok {
+		return commands, nil
+	}
+	b, err := json.Marshal(x)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &commands); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", commandsExtension, err)
+	}
+	for name, command := range commands {
+		if command.Service == "" {
+			return nil, fmt.Errorf("%s: command %q must set a service", commandsExtension, name)
+		}
+	}
+	return commands, nil
+}
+
+func runDo(ctx context.Context, backend compose.Service, p *projectOptions, name string, args []string) error {
+	project, err := p.toProject(nil)
+	if err != nil {
+		return err
+	}
+	commands, err := getProjectCommands(project)
+	if err != nil {
+		return err
+	}
+	command, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("no such command %q declared by %s", name, commandsExtension)
+	}
+	cmd, err := command.command()
+	if err != nil {
+		return err
+	}
+	cmd = append(cmd, args...)
+	env, err := command.environment()
+	if err != nil {
+		return err
+	}
+
+	if command.Exec {
+		return runExec(ctx, backend, execOpts{
+			composeOptions: &composeOptions{projectOptions: p},
+			service:        command.Service,
+			command:        cmd,
+			environment:    env,
+			workingDir:     command.WorkingDir,
+			user:           command.User,
+			noTty:          notAtTTY(),
+			index:          1,
+		})
+	}
+
+	project, err = p.toProject([]string{command.Service})
+	if err != nil {
+		return err
+	}
+	return runRun(ctx, backend, project, runOptions{
+		composeOptions: &composeOptions{projectOptions: p},
+		Service:        command.Service,
+		Command:        cmd,
+		environment:    env,
+		workdir:        command.WorkingDir,
+		user:           command.User,
+		Remove:         true,
+		noTty:          notAtTTY(),
+		noDeps:         command.Deps != nil && !*command.Deps,
+	})
+}
+
+func (c projectCommand) command() ([]string, error) {
+	switch cmd := c.Command.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return shellwords.Parse(cmd)
+	case []interface{}:
+		var command []string
+		for _, s := range cmd {
+			command = append(command, fmt.Sprint(s))
+		}
+		return command, nil
+	default:
+		return nil, fmt.Errorf("invalid command %v, must be a string or a list", cmd)
+	}
+}
+
+func (c projectCommand) environment() ([]string, error) {
+	switch env := c.Environment.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		var environment []string
+		for k, v := range env {
+			if v == nil {
+				environment = append(environment, k)
+				continue
+			}
+			environment = append(environment, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(environment)
+		return environment, nil
+	case []interface{}:
+		var environment []string
+		for _, e := range env {
+			environment = append(environment, fmt.Sprint(e))
+		}
+		return environment, nil
+	default:
+		return nil, fmt.Errorf("invalid environment %v, must be a mapping or a list", env)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/cli"
+	"gotest.tools/v3/assert"
+)
+
+func TestProjectCommands(t *testing.T) {
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(`
+services:
+  app:
+    image: rails
+x-commands:
+  migrate:
+    description: Run database migrations
+    service: app
+    command: bin/rails db:migrate
+    environment:
+      RAILS_ENV: production
+    deps: false
+  console:
+    service: app
+    command: ["bin/rails", "console"]
+    environment:
+      - DEBUG=1
+    exec: true
+`), 0644)
+	assert.NilError(t, err)
+
+	options, err := cli.NewProjectOptions([]string{filepath.Join(dir, "compose.yaml")}, cli.WithName("test"))
+	assert.NilError(t, err)
+	project, err := cli.ProjectFromOptions(options)
+	assert.NilError(t, err)
+
+	commands, err := getProjectCommands(project)
+	assert.NilError(t, err)
+	assert.Equal(t, len(commands), 2)
+
+	migrate := commands["migrate"]
+	assert.Equal(t, migrate.Description, "Run database migrations")
+	assert.Equal(t, migrate.Service, "app")
+	assert.Equal(t, *migrate.Deps, false)
+	cmd, err := migrate.command()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cmd, []string{"bin/rails", "db:migrate"})
+	env, err := migrate.environment()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, []string{"RAILS_ENV=production"})
+
+	console := commands["console"]
+	assert.Equal(t, console.Exec, true)
+	assert.Assert(t, console.Deps == nil)
+	cmd, err = console.command()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cmd, []string{"bin/rails", "console"})
+	env, err = console.environment()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, env, []string{"DEBUG=1"})
+}
@@ -28,7 +28,10 @@ import (
 
 type eventsOpts struct {
 	*composeOptions
-	json bool
+	json  bool
+	since string
+	until string
+	types []string
 }
 
 func eventsCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -46,6 +49,9 @@ func eventsCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&opts.json, "json", false, "Output events as a stream of json objects")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Show events since timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Stream events until timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().StringArrayVar(&opts.types, "type", []string{}, "Only show events of this type (e.g. die, oom, health_status, restart)")
 	return cmd
 }
 
@@ -57,16 +63,23 @@ func runEvents(ctx context.Context, backend compose.Service, opts eventsOpts, se
 
 	return backend.Events(ctx, project, compose.EventsOptions{
 		Services: services,
+		Since:    opts.since,
+		Until:    opts.until,
+		Types:    opts.types,
 		Consumer: func(event compose.Event) error {
 			if opts.json {
-				marshal, err := json.Marshal(map[string]interface{}{
+				data := map[string]interface{}{
 					"time":       event.Timestamp,
 					"type":       "container",
 					"service":    event.Service,
 					"id":         event.Container,
 					"action":     event.Status,
 					"attributes": event.Attributes,
-				})
+				}
+				if event.Status == compose.EventDie {
+					data["exitCode"] = event.ExitCode
+				}
+				marshal, err := json.Marshal(data)
 				if err != nil {
 					return err
 				}
@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/containerd/console"
 	"github.com/docker/cli/cli"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/cli/formatter"
 )
 
 type execOpts struct {
@@ -41,6 +43,7 @@ type execOpts struct {
 	detach     bool
 	index      int
 	privileged bool
+	all        bool
 }
 
 func execCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -66,6 +69,7 @@ func execCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	runCmd.Flags().BoolVarP(&opts.detach, "detach", "d", false, "Detached mode: Run command in the background.")
 	runCmd.Flags().StringArrayVarP(&opts.environment, "env", "e", []string{}, "Set environment variables")
 	runCmd.Flags().IntVar(&opts.index, "index", 1, "index of the container if there are multiple instances of a service [default: 1].")
+	runCmd.Flags().BoolVar(&opts.all, "all", false, "Run command in all replicas of the service(s), SERVICE can then be a comma separated list of services.")
 	runCmd.Flags().BoolVarP(&opts.privileged, "privileged", "", false, "Give extended privileges to the process.")
 	runCmd.Flags().StringVarP(&opts.user, "user", "u", "", "Run the command as this user.")
 	runCmd.Flags().BoolVarP(&opts.noTty, "no-TTY", "T", notAtTTY(), "Disable pseudo-TTY allocation. By default `docker compose exec` allocates a TTY.")
@@ -96,6 +100,13 @@ func runExec(ctx context.Context, backend compose.Service, opts execOpts) error
 		Reader: os.Stdin,
 	}
 
+	if opts.all {
+		execOpts.All = true
+		execOpts.Tty = false
+		execOpts.Services = strings.Split(opts.service, ",")
+		execOpts.Consumer = formatter.NewLogConsumer(ctx, os.Stdout, true, true)
+	}
+
 	if execOpts.Tty {
 		con := console.Current()
 		if err := con.SetRaw(); err != nil {
@@ -36,7 +36,8 @@ import (
 
 type imageOptions struct {
 	*projectOptions
-	Quiet bool
+	Quiet  bool
+	Format string
 }
 
 func imagesCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -51,6 +52,7 @@ func imagesCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 		}),
 	}
 	imgCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
+	imgCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json] or a Go template.")
 	return imgCmd
 }
 
@@ -88,7 +90,7 @@ func runImages(ctx context.Context, backend compose.Service, opts imageOptions,
 		return images[i].ContainerName < images[j].ContainerName
 	})
 
-	return formatter.Print(images, formatter.PRETTY, os.Stdout,
+	return formatter.Print(images, opts.Format, os.Stdout,
 		func(w io.Writer) {
 			for _, img := range images {
 				id := stringid.TruncateID(img.ID)
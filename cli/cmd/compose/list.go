@@ -47,7 +47,7 @@ func listCommand(contextType string, backend compose.Service) *cobra.Command {
 			return runList(ctx, backend, opts)
 		}),
 	}
-	lsCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	lsCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json] or a Go template.")
 	lsCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs.")
 	lsCmd.Flags().Var(&opts.Filter, "filter", "Filter output based on conditions provided.")
 	if contextType == store.DefaultContextType {
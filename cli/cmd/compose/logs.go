@@ -18,8 +18,11 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/compose"
@@ -35,6 +38,14 @@ type logsOptions struct {
 	noColor    bool
 	noPrefix   bool
 	timestamps bool
+	since      string
+	until      string
+	grep       string
+	format     string
+	outputDir  string
+	maxSize    string
+	maxFiles   int
+	logSinksOptions
 }
 
 func logsCommand(p *projectOptions, contextType string, backend compose.Service) *cobra.Command {
@@ -53,10 +64,20 @@ func logsCommand(p *projectOptions, contextType string, backend compose.Service)
 	flags.BoolVar(&opts.noColor, "no-color", false, "Produce monochrome output.")
 	flags.BoolVar(&opts.noPrefix, "no-log-prefix", false, "Don't print prefix in logs.")
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps.")
+	flags.StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json].")
+	flags.StringVar(&opts.outputDir, "output-dir", "", "Write logs to one file per service in this directory, instead of the terminal.")
+	flags.StringVar(&opts.maxSize, "output-max-size", "10MB", "Maximum size of a log file before it gets rotated, when used with --output-dir.")
+	flags.IntVar(&opts.maxFiles, "output-max-files", 5, "Maximum number of rotated log files to keep per service, when used with --output-dir.")
+	opts.addLogSinksFlags(flags)
 
 	if contextType == store.DefaultContextType {
 		flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs for each container.")
 	}
+	if contextType != store.AciContextType {
+		flags.StringVar(&opts.since, "since", "", "Show logs since timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+		flags.StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+		flags.StringVar(&opts.grep, "grep", "", "Only show log lines matching a regular expression")
+	}
 	return logsCmd
 }
 
@@ -65,11 +86,54 @@ func runLogs(ctx context.Context, backend compose.Service, opts logsOptions, ser
 	if err != nil {
 		return err
 	}
-	consumer := formatter.NewLogConsumer(ctx, os.Stdout, !opts.noColor, !opts.noPrefix)
+
+	var newConsumer func(w io.Writer) compose.LogConsumer
+	switch opts.format {
+	case formatter.PRETTY, "":
+		newConsumer = func(w io.Writer) compose.LogConsumer {
+			return formatter.NewLogConsumer(ctx, w, !opts.noColor && opts.outputDir == "", !opts.noPrefix)
+		}
+	case formatter.JSON:
+		newConsumer = formatter.NewJSONLogConsumer
+	default:
+		return fmt.Errorf("unsupported format %q", opts.format)
+	}
+
+	consumer := newConsumer(os.Stdout)
+	if opts.outputDir != "" {
+		maxSize, err := units.FromHumanSize(opts.maxSize)
+		if err != nil {
+			return err
+		}
+		files, err := formatter.NewLogFiles(opts.outputDir, maxSize, opts.maxFiles, newConsumer)
+		if err != nil {
+			return err
+		}
+		defer files.Close() // nolint:errcheck
+		consumer = files
+	}
+
+	if opts.follow {
+		// project is only required to read the x-log-sinks extension, logs can be followed without a compose file
+		project, _ := opts.toProject(nil)
+		var closeSinks func()
+		consumer, closeSinks, err = opts.withLogSinks(consumer, project)
+		if err != nil {
+			return err
+		}
+		defer closeSinks()
+	} else if len(opts.logSinks) > 0 {
+		return fmt.Errorf("--log-sink can only be used with --follow")
+	}
+
 	return backend.Logs(ctx, projectName, consumer, compose.LogOptions{
-		Services:   services,
-		Follow:     opts.follow,
-		Tail:       opts.tail,
-		Timestamps: opts.timestamps,
+		Services: services,
+		Follow:   opts.follow,
+		Tail:     opts.tail,
+		// JSON entries have a timestamp attribute, which is parsed from log lines
+		Timestamps: opts.timestamps || opts.format == formatter.JSON,
+		Since:      opts.since,
+		Until:      opts.until,
+		Grep:       opts.grep,
 	})
 }
@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/pflag"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/cli/formatter"
+	"github.com/docker/compose-cli/utils"
+)
+
+const logSinksExtension = "x-log-sinks"
+
+type logSinksOptions struct {
+	logSinks []string
+}
+
+func (o *logSinksOptions) addLogSinksFlags(f *pflag.FlagSet) {
+	f.StringArrayVar(&o.logSinks, "log-sink", []string{}, "Also send logs to a sink: file:PATH, syslog[://ADDRESS], http(s)://URL or fluentd://ADDRESS.")
+}
+
+// sinkConfigs collects log sinks declared by the project x-log-sinks extension, then by --log-sink flags
+func (o *logSinksOptions) sinkConfigs(project *types.Project) ([]formatter.LogSinkConfig, error) {
+	var configs []formatter.LogSinkConfig
+	if project != nil {
+		if x, ok := project.Extensions[logSinksExtension]; ok {
+			b, err := json.Marshal(x)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(b, &configs); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", logSinksExtension, err)
+			}
+		}
+	}
+	for _, value := range o.logSinks {
+		config, err := formatter.ParseLogSink(value)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// withLogSinks fans out logs sent to consumer to the configured log sinks. Returned func closes the sinks.
+func (o *logSinksOptions) withLogSinks(consumer compose.LogConsumer, project *types.Project) (compose.LogConsumer, func(), error) {
+	configs, err := o.sinkConfigs(project)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sinks []formatter.LogSink
+	closeSinks := func() {
+		for _, sink := range sinks {
+			sink.Close() // nolint:errcheck
+		}
+	}
+	consumers := []compose.LogConsumer{consumer}
+	for _, config := range configs {
+		sink, err := formatter.NewLogSink(config)
+		if err != nil {
+			closeSinks()
+			return nil, nil, err
+		}
+		sinks = append(sinks, sink)
+		consumers = append(consumers, sink)
+	}
+	return utils.MultiLogConsumer(consumers...), closeSinks, nil
+}
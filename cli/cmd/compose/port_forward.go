@@ -0,0 +1,55 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type portForwardOptions struct {
+	*projectOptions
+	address string
+}
+
+func portForwardCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	opts := portForwardOptions{
+		projectOptions: p,
+	}
+	cmd := &cobra.Command{
+		Use:   "port-forward [options] [SERVICE...]",
+		Short: "Forward published ports of services to local ports, until interrupted.",
+		RunE: p.WithServices(func(ctx context.Context, project *types.Project, services []string) error {
+			return runPortForward(ctx, backend, opts, project, services)
+		}),
+	}
+	cmd.Flags().StringVar(&opts.address, "address", "127.0.0.1", "Address to bind local ports to.")
+	return cmd
+}
+
+func runPortForward(ctx context.Context, backend compose.Service, opts portForwardOptions, project *types.Project, services []string) error {
+	return backend.PortForward(ctx, project, compose.PortForwardOptions{
+		Services: services,
+		Address:  opts.address,
+		Writer:   os.Stdout,
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/docker/cli/opts"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/compose"
@@ -37,11 +38,13 @@ type psOptions struct {
 	All      bool
 	Quiet    bool
 	Services bool
+	Filter   opts.FilterOpt
 }
 
 func psCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	opts := psOptions{
 		projectOptions: p,
+		Filter:         opts.NewFilterOpt(),
 	}
 	psCmd := &cobra.Command{
 		Use:   "ps",
@@ -50,21 +53,37 @@ func psCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 			return runPs(ctx, backend, args, opts)
 		}),
 	}
-	psCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	psCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json] or a Go template.")
+	psCmd.Flags().Var(&opts.Filter, "filter", "Filter containers by a property (status, health, service, label). Filtering on status implies --all.")
 	psCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
 	psCmd.Flags().BoolVar(&opts.Services, "services", false, "Display services")
 	psCmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Show all stopped containers (including those created by the run command)")
 	return psCmd
 }
 
+var acceptedPsFilters = map[string]bool{
+	"status":  true,
+	"health":  true,
+	"service": true,
+	"label":   true,
+}
+
 func runPs(ctx context.Context, backend compose.Service, services []string, opts psOptions) error {
 	projectName, err := opts.toProjectName()
 	if err != nil {
 		return err
 	}
+	filters := opts.Filter.Value()
+	err = filters.Validate(acceptedPsFilters)
+	if err != nil {
+		return err
+	}
 	containers, err := backend.Ps(ctx, projectName, compose.PsOptions{
-		All:      opts.All,
-		Services: services,
+		All:      opts.All || len(filters.Get("status")) > 0,
+		Services: append(services, filters.Get("service")...),
+		Status:   filters.Get("status"),
+		Health:   filters.Get("health"),
+		Labels:   filters.Get("label"),
 	})
 	if err != nil {
 		return err
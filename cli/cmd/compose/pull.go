@@ -36,6 +36,7 @@ type pullOptions struct {
 	noParallel         bool
 	includeDeps        bool
 	ignorePullFailures bool
+	parallelism        int
 }
 
 func pullCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -63,6 +64,7 @@ func pullCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.parallel, "no-parallel", true, "DEPRECATED disable parallel pulling.")
 	flags.MarkHidden("no-parallel") //nolint:errcheck
 	cmd.Flags().BoolVar(&opts.ignorePullFailures, "ignore-pull-failures", false, "Pull what it can and ignores images with pull failures")
+	flags.IntVar(&opts.parallelism, "parallelism", 0, "Maximum number of images to pull concurrently (0 for no limit)")
 	return cmd
 }
 
@@ -88,5 +90,6 @@ func runPull(ctx context.Context, backend compose.Service, opts pullOptions, ser
 	return backend.Pull(ctx, project, compose.PullOptions{
 		Quiet:          opts.quiet,
 		IgnoreFailures: opts.ignorePullFailures,
+		Parallelism:    opts.parallelism,
 	})
 }
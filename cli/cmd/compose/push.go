@@ -18,10 +18,15 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/cli/formatter"
 )
 
 type pushOptions struct {
@@ -29,6 +34,9 @@ type pushOptions struct {
 	composeOptions
 
 	Ignorefailures bool
+	Tags           []string
+	Parallelism    int
+	Format         string
 }
 
 func pushCommand(p *projectOptions, backend compose.Service) *cobra.Command {
@@ -43,6 +51,9 @@ func pushCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 		}),
 	}
 	pushCmd.Flags().BoolVar(&opts.Ignorefailures, "ignore-push-failures", false, "Push what it can and ignores images with push failures")
+	pushCmd.Flags().StringArrayVar(&opts.Tags, "tag", []string{}, "Additional tag to apply to service images and push")
+	pushCmd.Flags().IntVar(&opts.Parallelism, "parallelism", 0, "Maximum number of concurrent pushes per registry (0 for no limit)")
+	pushCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the summary of pushed images. Values: [pretty | json].")
 
 	return pushCmd
 }
@@ -53,7 +64,32 @@ func runPush(ctx context.Context, backend compose.Service, opts pushOptions, ser
 		return err
 	}
 
-	return backend.Push(ctx, project, compose.PushOptions{
+	var pushed []compose.PushSummary
+	err = backend.Push(ctx, project, compose.PushOptions{
 		IgnoreFailures: opts.Ignorefailures,
+		Tags:           opts.Tags,
+		Parallelism:    opts.Parallelism,
+		Summary: func(summary compose.PushSummary) {
+			pushed = append(pushed, summary)
+		},
 	})
+	if err != nil || len(pushed) == 0 {
+		return err
+	}
+
+	sort.Slice(pushed, func(i, j int) bool {
+		return pushed[i].Image < pushed[j].Image
+	})
+
+	return formatter.Print(pushed, opts.Format, os.Stdout,
+		func(w io.Writer) {
+			for _, summary := range pushed {
+				status := "pushed"
+				if summary.Skipped {
+					status = "up to date"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", summary.Service, summary.Image, summary.Digest, status)
+			}
+		},
+		"SERVICE", "IMAGE", "DIGEST", "STATUS")
 }
@@ -47,6 +47,7 @@ type upOptions struct {
 	noColor            bool
 	noPrefix           bool
 	attachDependencies bool
+	logSinksOptions
 }
 
 func (opts upOptions) apply(project *types.Project, services []string) error {
@@ -108,6 +109,9 @@ func upCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 			if up.Detach && (up.attachDependencies || up.cascadeStop) {
 				return fmt.Errorf("--detach cannot be combined with --abort-on-container-exit or --attach-dependencies")
 			}
+			if up.Detach && len(up.logSinks) > 0 {
+				return fmt.Errorf("--detach cannot be combined with --log-sink")
+			}
 			if create.forceRecreate && create.noRecreate {
 				return fmt.Errorf("--force-recreate and --no-recreate are incompatible")
 			}
@@ -131,6 +135,7 @@ func upCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	flags.BoolVar(&up.noPrefix, "no-log-prefix", false, "Don't print prefix in logs.")
 	flags.BoolVar(&create.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed.")
 	flags.BoolVar(&create.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
+	flags.BoolVar(&create.recreateNets, "recreate-networks", false, "Recreate networks which configuration changed, detaching and reattaching containers.")
 	flags.BoolVar(&up.noStart, "no-start", false, "Don't start the services after creating them.")
 	flags.BoolVar(&up.cascadeStop, "abort-on-container-exit", false, "Stops all containers if any container was stopped. Incompatible with -d")
 	flags.StringVar(&up.exitCodeFrom, "exit-code-from", "", "Return the exit code of the selected service container. Implies --abort-on-container-exit")
@@ -140,6 +145,8 @@ func upCommand(p *projectOptions, backend compose.Service) *cobra.Command {
 	flags.BoolVarP(&create.noInherit, "renew-anon-volumes", "V", false, "Recreate anonymous volumes instead of retrieving data from the previous containers.")
 	flags.BoolVar(&up.attachDependencies, "attach-dependencies", false, "Attach to dependent containers.")
 	flags.BoolVar(&create.quietPull, "quiet-pull", false, "Pull without printing progress information.")
+	flags.IntVar(&create.pullParallel, "pull-parallelism", 0, "Maximum number of images to pull concurrently (0 for no limit).")
+	up.addLogSinksFlags(flags)
 
 	return upCmd
 }
@@ -159,6 +166,12 @@ func runUp(ctx context.Context, backend compose.Service, createOptions createOpt
 	var consumer compose.LogConsumer
 	if !upOptions.Detach {
 		consumer = formatter.NewLogConsumer(ctx, os.Stdout, !upOptions.noColor, !upOptions.noPrefix)
+		var closeSinks func()
+		consumer, closeSinks, err = upOptions.withLogSinks(consumer, project)
+		if err != nil {
+			return err
+		}
+		defer closeSinks()
 	}
 
 	attachTo := services
@@ -173,6 +186,8 @@ func runUp(ctx context.Context, backend compose.Service, createOptions createOpt
 		Inherit:              !createOptions.noInherit,
 		Timeout:              createOptions.GetTimeout(),
 		QuietPull:            createOptions.quietPull,
+		PullParallelism:      createOptions.pullParallel,
+		RecreateNetworks:     createOptions.recreateNets,
 	}
 
 	if upOptions.noStart {
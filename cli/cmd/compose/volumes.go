@@ -0,0 +1,109 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func volumesCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volumes",
+		Short: "Manage project named volumes",
+	}
+	cmd.AddCommand(
+		volumesBackupCommand(p, backend),
+		volumesRestoreCommand(p, backend),
+	)
+	return cmd
+}
+
+type volumesBackupOptions struct {
+	*projectOptions
+	output string
+}
+
+func volumesBackupCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	opts := volumesBackupOptions{
+		projectOptions: p,
+	}
+	cmd := &cobra.Command{
+		Use:   "backup [VOLUME...]",
+		Short: "Archive project named volumes as tar.gz files, with a manifest",
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runVolumesBackup(ctx, backend, opts, args)
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Directory to write backup to [default: PROJECT-volumes-TIMESTAMP]")
+	return cmd
+}
+
+func runVolumesBackup(ctx context.Context, backend compose.Service, opts volumesBackupOptions, volumes []string) error {
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	output := opts.output
+	if output == "" {
+		output = fmt.Sprintf("%s-volumes-%s", projectName, time.Now().Format("20060102150405"))
+	}
+	return backend.BackupVolumes(ctx, projectName, compose.BackupVolumesOptions{
+		Output:  output,
+		Volumes: volumes,
+	})
+}
+
+type volumesRestoreOptions struct {
+	*projectOptions
+	input string
+	force bool
+}
+
+func volumesRestoreCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	opts := volumesRestoreOptions{
+		projectOptions: p,
+	}
+	cmd := &cobra.Command{
+		Use:   "restore --input DIR [VOLUME...]",
+		Short: "Recreate project named volumes from a backup",
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runVolumesRestore(ctx, backend, opts, args)
+		}),
+	}
+	cmd.Flags().StringVarP(&opts.input, "input", "i", "", "Directory backup has been written to")
+	cmd.MarkFlagRequired("input") //nolint:errcheck
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Don't ask to confirm existing volumes replacement")
+	return cmd
+}
+
+func runVolumesRestore(ctx context.Context, backend compose.Service, opts volumesRestoreOptions, volumes []string) error {
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	return backend.RestoreVolumes(ctx, projectName, compose.RestoreVolumesOptions{
+		Input:   opts.input,
+		Volumes: volumes,
+		Force:   opts.force,
+	})
+}
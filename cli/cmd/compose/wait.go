@@ -0,0 +1,74 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+
+	"github.com/docker/cli/cli"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type waitOptions struct {
+	*projectOptions
+	all         bool
+	downProject bool
+}
+
+func waitCommand(p *projectOptions, backend compose.Service) *cobra.Command {
+	opts := waitOptions{
+		projectOptions: p,
+	}
+	cmd := &cobra.Command{
+		Use:   "wait [SERVICE...]",
+		Short: "Block until service containers exit, and return their exit code",
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runWait(ctx, backend, opts, args)
+		}),
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Wait for all containers to exit, and return the worst exit code.")
+	cmd.Flags().BoolVar(&opts.downProject, "down-project", false, "Stop and remove the project once containers exited.")
+	return cmd
+}
+
+func runWait(ctx context.Context, backend compose.Service, opts waitOptions, services []string) error {
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := backend.Wait(ctx, projectName, compose.WaitOptions{
+		Services: services,
+		All:      opts.all,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.downProject {
+		err = backend.Down(ctx, projectName, compose.DownOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	if exitCode != 0 {
+		return cli.StatusError{StatusCode: exitCode}
+	}
+	return nil
+}
@@ -51,6 +51,7 @@ func createKubeCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.KubeConfigPath, "kubeconfig", "", "The endpoint of the Kubernetes manager")
 	cmd.Flags().StringVar(&opts.KubeContextName, "kubecontext", "", "The name of the context to use in kubeconfig")
 	cmd.Flags().BoolVar(&opts.FromEnvironment, "from-env", false, "Get endpoint and creds from env vars")
+	cmd.Flags().StringVar(&opts.Registry, "registry", "", "Registry repository prefix images are built for and pushed to, e.g. registry.example.com/team")
 	return cmd
 }
 
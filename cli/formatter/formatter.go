@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/docker/cli/templates"
 	"github.com/pkg/errors"
 
 	"github.com/docker/compose-cli/api/errdefs"
@@ -67,7 +68,34 @@ func Print(toJSON interface{}, format string, outWriter io.Writer, writerFn func
 			_, _ = fmt.Fprintln(outWriter, outJSON)
 		}
 	default:
-		return errors.Wrapf(errdefs.ErrParsingFailed, "format value %q could not be parsed", format)
+		if !strings.Contains(format, "{{") {
+			return errors.Wrapf(errdefs.ErrParsingFailed, "format value %q could not be parsed", format)
+		}
+		return printTemplate(toJSON, format, outWriter)
+	}
+	return nil
+}
+
+// printTemplate renders a Go template for each element of a list, or once for other objects
+func printTemplate(toTemplate interface{}, format string, outWriter io.Writer) error {
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return errors.Wrapf(errdefs.ErrParsingFailed, "template %q could not be parsed: %v", format, err)
+	}
+	var items []interface{}
+	if reflect.TypeOf(toTemplate).Kind() == reflect.Slice {
+		s := reflect.ValueOf(toTemplate)
+		for i := 0; i < s.Len(); i++ {
+			items = append(items, s.Index(i).Interface())
+		}
+	} else {
+		items = append(items, toTemplate)
+	}
+	for _, item := range items {
+		if err := tmpl.Execute(outWriter, item); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(outWriter)
 	}
 	return nil
 }
@@ -71,3 +71,23 @@ func TestPrint(t *testing.T) {
 {"Name":"myName2","Status":"myStatus2"}
 `)
 }
+
+func TestPrintTemplate(t *testing.T) {
+	testList := []testStruct{
+		{
+			Name:   "myName1",
+			Status: "myStatus1",
+		},
+		{
+			Name:   "myName2",
+			Status: "myStatus2",
+		},
+	}
+
+	b := &bytes.Buffer{}
+	assert.NilError(t, Print(testList, "{{.Name}}: {{upper .Status}}", b, nil))
+	assert.Equal(t, b.String(), "myName1: MYSTATUS1\nmyName2: MYSTATUS2\n")
+
+	assert.ErrorContains(t, Print(testList, "{{.Name", b, nil), "could not be parsed")
+	assert.ErrorContains(t, Print(testList, "unknown", b, nil), "could not be parsed")
+}
@@ -0,0 +1,185 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// LogFiles is a LogConsumer writing logs into one file per service, rotated when they reach a maximum size
+type LogFiles struct {
+	mu          sync.Mutex
+	dir         string
+	maxSize     int64
+	maxFiles    int
+	newConsumer func(w io.Writer) compose.LogConsumer
+	services    map[string]*serviceLogFile
+	containers  map[string]string
+}
+
+type serviceLogFile struct {
+	consumer compose.LogConsumer
+	file     *rotatingFile
+}
+
+// NewLogFiles creates a LogFiles consumer writing to dir. Log lines are formatted by the LogConsumer created by
+// newConsumer for each service file. Files are rotated when they exceed maxSize bytes (0 for no rotation), and at
+// most maxFiles rotated files are kept for each service
+func NewLogFiles(dir string, maxSize int64, maxFiles int, newConsumer func(w io.Writer) compose.LogConsumer) (*LogFiles, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &LogFiles{
+		dir:         dir,
+		maxSize:     maxSize,
+		maxFiles:    maxFiles,
+		newConsumer: newConsumer,
+		services:    map[string]*serviceLogFile{},
+		containers:  map[string]string{},
+	}, nil
+}
+
+// Log writes a log message to service log file
+func (l *LogFiles) Log(container, service, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f := l.get(container, service); f != nil {
+		f.consumer.Log(container, service, message)
+	}
+}
+
+// Err writes a log message written on stderr to service log file
+func (l *LogFiles) Err(container, service, message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f := l.get(container, service); f != nil {
+		f.consumer.Err(container, service, message)
+	}
+}
+
+// Status writes a container status message to service log file, if we already got logs from this container
+func (l *LogFiles) Status(container, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	service, ok := l.containers[container]
+	if !ok {
+		return
+	}
+	if f := l.get(container, service); f != nil {
+		f.consumer.Status(container, msg)
+	}
+}
+
+// Register is a noop, as log files are created on first log message for a service
+func (l *LogFiles) Register(container string) {}
+
+// Close closes all log files
+func (l *LogFiles) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs *multierror.Error
+	for _, f := range l.services {
+		errs = multierror.Append(errs, f.file.Close())
+	}
+	return errs.ErrorOrNil()
+}
+
+// get returns the log file for service, creating it on first call. Must be called with lock held
+func (l *LogFiles) get(container, service string) *serviceLogFile {
+	l.containers[container] = service
+	if f, ok := l.services[service]; ok {
+		return f
+	}
+	file, err := newRotatingFile(filepath.Join(l.dir, service+".log"), l.maxSize, l.maxFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write logs for service %s: %v\n", service, err)
+		return nil
+	}
+	f := &serviceLogFile{
+		consumer: l.newConsumer(file),
+		file:     file,
+	}
+	l.services[service] = f
+	return f
+}
+
+// rotatingFile is an io.Writer which renames file with a numbered suffix when it reaches maxSize
+type rotatingFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxFiles int) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	return r, r.open()
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.size = 0
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if r.maxFiles > 0 {
+		_ = os.Remove(fmt.Sprintf("%s.%d", r.path, r.maxFiles))
+		for i := r.maxFiles - 1; i > 0; i-- {
+			_ = os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+		}
+		if err := os.Rename(r.path, r.path+".1"); err != nil {
+			return err
+		}
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	return r.file.Close()
+}
@@ -18,10 +18,13 @@ package formatter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/compose-cli/api/compose"
 )
@@ -75,6 +78,11 @@ func (l *logConsumer) Log(container, service, message string) {
 	}
 }
 
+// Err formats a log message written on stderr, which is rendered the same way as stdout
+func (l *logConsumer) Err(container, service, message string) {
+	l.Log(container, service, message)
+}
+
 func (l *logConsumer) Status(container, msg string) {
 	p, ok := l.presenters[container]
 	if !ok {
@@ -113,3 +121,64 @@ type presenter struct {
 func (p *presenter) setPrefix(width int) {
 	p.prefix = p.colors(fmt.Sprintf("%-"+strconv.Itoa(width)+"s |", p.name))
 }
+
+// LogEntry is the JSON representation of a log line
+type LogEntry struct {
+	Service   string    `json:"service"`
+	Container string    `json:"container"`
+	Timestamp time.Time `json:"timestamp"`
+	Stream    string    `json:"stream"`
+	Message   string    `json:"message"`
+}
+
+// NewLogEntry creates a LogEntry for a log line, using the RFC3339 timestamp it is prefixed with if set
+func NewLogEntry(container, service, stream, message string) LogEntry {
+	entry := LogEntry{
+		Service:   service,
+		Container: container,
+		Timestamp: time.Now().UTC(),
+		Stream:    stream,
+		Message:   message,
+	}
+	if parts := strings.SplitN(message, " ", 2); len(parts) == 2 {
+		if timestamp, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+			entry.Timestamp = timestamp
+			entry.Message = parts[1]
+		}
+	}
+	return entry
+}
+
+// NewJSONLogConsumer creates a LogConsumer writing log lines as JSON objects, one per line. Backends are expected
+// to prefix log lines with their RFC3339 timestamp, otherwise the time log lines are received is used.
+func NewJSONLogConsumer(w io.Writer) compose.LogConsumer {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonLogConsumer{
+		encoder: encoder,
+	}
+}
+
+type jsonLogConsumer struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func (l *jsonLogConsumer) Log(container, service, message string) {
+	l.write(container, service, "stdout", message)
+}
+
+func (l *jsonLogConsumer) Err(container, service, message string) {
+	l.write(container, service, "stderr", message)
+}
+
+func (l *jsonLogConsumer) write(container, service, stream, message string) {
+	entry := NewLogEntry(container, service, stream, message)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.encoder.Encode(entry) // nolint:errcheck
+}
+
+func (l *jsonLogConsumer) Status(container, msg string) {}
+
+func (l *jsonLogConsumer) Register(container string) {}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestJSONLogConsumer(t *testing.T) {
+	b := &bytes.Buffer{}
+	consumer := NewJSONLogConsumer(b)
+	consumer.Log("web_1", "web", "2021-06-10T14:35:33.123456789Z listening on :80")
+	consumer.Err("web_1", "web", "2021-06-10T14:35:34Z oops")
+
+	dec := json.NewDecoder(b)
+	var entry LogEntry
+	assert.NilError(t, dec.Decode(&entry))
+	assert.DeepEqual(t, entry, LogEntry{
+		Service:   "web",
+		Container: "web_1",
+		Timestamp: time.Date(2021, 6, 10, 14, 35, 33, 123456789, time.UTC),
+		Stream:    "stdout",
+		Message:   "listening on :80",
+	})
+	assert.NilError(t, dec.Decode(&entry))
+	assert.Equal(t, entry.Stream, "stderr")
+	assert.Equal(t, entry.Message, "oops")
+}
+
+func TestLogFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	files, err := NewLogFiles(dir, 10, 2, func(w io.Writer) compose.LogConsumer {
+		return NewLogConsumer(context.Background(), w, false, false)
+	})
+	assert.NilError(t, err)
+	files.Log("web_1", "web", "first")
+	files.Log("db_1", "db", "ready")
+	files.Log("web_2", "web", "second")
+	files.Log("web_1", "web", "third")
+	assert.NilError(t, files.Close())
+
+	assertFile := func(name string, content string) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), content)
+	}
+	assertFile("db.log", " ready\n")
+	assertFile("web.log", " third\n")
+	assertFile("web.log.1", " second\n")
+	assertFile("web.log.2", " first\n")
+}
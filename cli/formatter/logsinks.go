@@ -0,0 +1,456 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+const (
+	// FileLogSink appends log lines to a file, as JSON objects
+	FileLogSink = "file"
+	// SyslogLogSink sends log lines to syslog
+	SyslogLogSink = "syslog"
+	// HTTPLogSink posts batches of log lines to an HTTP endpoint, as JSON arrays
+	HTTPLogSink = "http"
+	// FluentdLogSink sends log lines to fluentd using the forward protocol
+	FluentdLogSink = "fluentd"
+)
+
+// LogSink is a LogConsumer forwarding log lines to an external destination
+type LogSink interface {
+	compose.LogConsumer
+	io.Closer
+}
+
+// LogSinkConfig configures a LogSink, as set by `--log-sink` flag or `x-log-sinks` extension
+type LogSinkConfig struct {
+	// Type is one of file, syslog, http or fluentd
+	Type string `json:"type"`
+	// Path is the file log lines are appended to
+	Path string `json:"path,omitempty"`
+	// URL is the HTTP endpoint log lines are posted to
+	URL string `json:"url,omitempty"`
+	// Address is the syslog or fluentd server address, as [tcp://|udp://|unix://]host:port
+	Address string `json:"address,omitempty"`
+	// Tag is the syslog tag, or the fluentd tag prefix
+	Tag string `json:"tag,omitempty"`
+	// BatchSize is the maximum number of log lines posted at once to an HTTP endpoint
+	BatchSize int `json:"batch_size,omitempty"`
+	// FlushInterval is the maximum delay before log lines are posted to an HTTP endpoint
+	FlushInterval string `json:"flush_interval,omitempty"`
+}
+
+// ParseLogSink parses a `--log-sink` flag value: file:PATH, syslog[://ADDRESS], http(s)://URL or fluentd://ADDRESS
+func ParseLogSink(value string) (LogSinkConfig, error) {
+	switch {
+	case strings.HasPrefix(value, "file:"):
+		return LogSinkConfig{Type: FileLogSink, Path: strings.TrimPrefix(strings.TrimPrefix(value, "file:"), "//")}, nil
+	case value == SyslogLogSink:
+		return LogSinkConfig{Type: SyslogLogSink}, nil
+	case strings.HasPrefix(value, "syslog://"):
+		return LogSinkConfig{Type: SyslogLogSink, Address: strings.TrimPrefix(value, "syslog://")}, nil
+	case strings.HasPrefix(value, "syslog+tcp://"):
+		return LogSinkConfig{Type: SyslogLogSink, Address: "tcp://" + strings.TrimPrefix(value, "syslog+tcp://")}, nil
+	case strings.HasPrefix(value, "http://"), strings.HasPrefix(value, "https://"):
+		return LogSinkConfig{Type: HTTPLogSink, URL: value}, nil
+	case strings.HasPrefix(value, "fluentd://"):
+		return LogSinkConfig{Type: FluentdLogSink, Address: strings.TrimPrefix(value, "fluentd://")}, nil
+	}
+	return LogSinkConfig{}, fmt.Errorf("unsupported log sink %q", value)
+}
+
+// NewLogSink creates a LogSink
+func NewLogSink(config LogSinkConfig) (LogSink, error) {
+	switch config.Type {
+	case FileLogSink:
+		return newFileLogSink(config)
+	case SyslogLogSink:
+		return newSyslogLogSink(config)
+	case HTTPLogSink:
+		return newHTTPLogSink(config)
+	case FluentdLogSink:
+		return newFluentdLogSink(config)
+	default:
+		return nil, fmt.Errorf("unsupported log sink type %q", config.Type)
+	}
+}
+
+func splitSinkAddress(address string, defaultNetwork string) (string, string) {
+	if parts := strings.SplitN(address, "://", 2); len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return defaultNetwork, address
+}
+
+type fileLogSink struct {
+	compose.LogConsumer
+	file *os.File
+}
+
+func newFileLogSink(config LogSinkConfig) (LogSink, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("file log sink requires a path")
+	}
+	f, err := os.OpenFile(config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileLogSink{
+		LogConsumer: NewJSONLogConsumer(f),
+		file:        f,
+	}, nil
+}
+
+func (s *fileLogSink) Close() error {
+	return s.file.Close()
+}
+
+// httpMaxBatches is the number of batches kept while the http endpoint is slow or unreachable, before the oldest
+// entries get dropped
+const httpMaxBatches = 10
+
+type httpLogSink struct {
+	url       string
+	client    *http.Client
+	batchSize int
+	mu        sync.Mutex
+	batch     []LogEntry
+	dropped   int
+	full      chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newHTTPLogSink(config LogSinkConfig) (LogSink, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("http log sink requires an url")
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	interval := time.Second
+	if config.FlushInterval != "" {
+		d, err := time.ParseDuration(config.FlushInterval)
+		if err != nil {
+			return nil, err
+		}
+		interval = d
+	}
+	s := &httpLogSink{
+		url:       config.URL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		batchSize: batchSize,
+		full:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run(interval)
+	return s, nil
+}
+
+func (s *httpLogSink) run(interval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.send()
+		case <-s.full:
+			s.send()
+		case <-s.done:
+			s.send()
+			return
+		}
+	}
+}
+
+func (s *httpLogSink) add(entry LogEntry) {
+	s.mu.Lock()
+	if len(s.batch) >= s.batchSize*httpMaxBatches {
+		if s.dropped == 0 {
+			logrus.Warnf("%s is too slow, dropping oldest log entries", s.url)
+		}
+		s.dropped++
+		copy(s.batch, s.batch[1:])
+		s.batch = s.batch[:len(s.batch)-1]
+	}
+	s.batch = append(s.batch, entry)
+	full := len(s.batch) >= s.batchSize
+	s.mu.Unlock()
+	if full {
+		select {
+		case s.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *httpLogSink) send() {
+	s.mu.Lock()
+	entries := s.batch
+	s.batch = nil
+	s.mu.Unlock()
+	for len(entries) > 0 {
+		n := len(entries)
+		if n > s.batchSize {
+			n = s.batchSize
+		}
+		s.post(entries[:n])
+		entries = entries[n:]
+	}
+}
+
+func (s *httpLogSink) post(batch []LogEntry) {
+	b, err := json.Marshal(batch)
+	if err != nil {
+		logrus.Warnf("failed to encode logs: %v", err)
+		return
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		logrus.Warnf("failed to send logs to %s: %v", s.url, err)
+		return
+	}
+	resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode >= 300 {
+		logrus.Warnf("failed to send logs to %s: %s", s.url, resp.Status)
+		return
+	}
+	s.mu.Lock()
+	s.dropped = 0
+	s.mu.Unlock()
+}
+
+func (s *httpLogSink) Log(container, service, message string) {
+	s.add(NewLogEntry(container, service, "stdout", message))
+}
+
+func (s *httpLogSink) Err(container, service, message string) {
+	s.add(NewLogEntry(container, service, "stderr", message))
+}
+
+func (s *httpLogSink) Status(container, msg string) {}
+
+func (s *httpLogSink) Register(container string) {}
+
+func (s *httpLogSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+const (
+	// fluentdBufferSize is the number of messages queued while fluentd is slow or unreachable, before lines get dropped
+	fluentdBufferSize = 1024
+	// fluentdTimeout bounds connection and write to fluentd
+	fluentdTimeout = 5 * time.Second
+	// fluentdRetryDelay is the delay before trying to reconnect to fluentd after a failure
+	fluentdRetryDelay = 10 * time.Second
+)
+
+type fluentdLogSink struct {
+	network string
+	address string
+	tag     string
+	conn    net.Conn
+	retry   time.Time
+	queue   chan []byte
+	done    chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	dropped int
+}
+
+func newFluentdLogSink(config LogSinkConfig) (LogSink, error) {
+	address := config.Address
+	if address == "" {
+		address = "localhost:24224"
+	}
+	tag := config.Tag
+	if tag == "" {
+		tag = "compose"
+	}
+	network, address := splitSinkAddress(address, "tcp")
+	conn, err := net.DialTimeout(network, address, fluentdTimeout)
+	if err != nil {
+		return nil, err
+	}
+	s := &fluentdLogSink{
+		network: network,
+		address: address,
+		tag:     tag,
+		conn:    conn,
+		queue:   make(chan []byte, fluentdBufferSize),
+		done:    make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// write queues message without blocking, so a slow or unreachable fluentd doesn't hold logs back
+func (s *fluentdLogSink) write(container, service, stream, message string) {
+	entry := NewLogEntry(container, service, stream, message)
+	buf := bytes.Buffer{}
+	encodeFluentdMessage(&buf, s.tag+"."+service, entry.Timestamp, map[string]string{
+		"container_name": container,
+		"service":        service,
+		"source":         stream,
+		"log":            entry.Message,
+	})
+
+	select {
+	case s.queue <- buf.Bytes():
+	default:
+		s.mu.Lock()
+		if s.dropped == 0 {
+			logrus.Warnf("fluentd %s is too slow, dropping log lines", s.address)
+		}
+		s.dropped++
+		s.mu.Unlock()
+	}
+}
+
+func (s *fluentdLogSink) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case b := <-s.queue:
+			s.send(b)
+		case <-s.done:
+			for {
+				select {
+				case b := <-s.queue:
+					s.send(b)
+				default:
+					if s.conn != nil {
+						s.conn.Close() // nolint:errcheck
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *fluentdLogSink) send(b []byte) {
+	if s.conn == nil {
+		if time.Now().Before(s.retry) {
+			return
+		}
+		conn, err := net.DialTimeout(s.network, s.address, fluentdTimeout)
+		if err != nil {
+			logrus.Warnf("failed to connect to fluentd %s: %v", s.address, err)
+			s.retry = time.Now().Add(fluentdRetryDelay)
+			return
+		}
+		s.conn = conn
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(fluentdTimeout)) // nolint:errcheck
+	if _, err := s.conn.Write(b); err != nil {
+		logrus.Warnf("failed to send logs to fluentd %s: %v", s.address, err)
+		s.conn.Close() // nolint:errcheck
+		s.conn = nil
+		s.retry = time.Now().Add(fluentdRetryDelay)
+		return
+	}
+	s.mu.Lock()
+	s.dropped = 0
+	s.mu.Unlock()
+}
+
+func (s *fluentdLogSink) Log(container, service, message string) {
+	s.write(container, service, "stdout", message)
+}
+
+func (s *fluentdLogSink) Err(container, service, message string) {
+	s.write(container, service, "stderr", message)
+}
+
+func (s *fluentdLogSink) Status(container, msg string) {}
+
+func (s *fluentdLogSink) Register(container string) {}
+
+func (s *fluentdLogSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+// encodeFluentdMessage writes a forward protocol message `[tag, time, record]`, encoded with msgpack
+func encodeFluentdMessage(buf *bytes.Buffer, tag string, timestamp time.Time, record map[string]string) {
+	buf.WriteByte(0x93) // fixarray, 3 elements
+	encodeMsgpackString(buf, tag)
+	// uint32
+	buf.WriteByte(0xce)
+	binary.Write(buf, binary.BigEndian, uint32(timestamp.Unix())) // nolint:errcheck
+
+	keys := make([]string, 0, len(record))
+	for k := range record {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) < 16 {
+		buf.WriteByte(0x80 | byte(len(keys))) // fixmap
+	} else {
+		// map16
+		buf.WriteByte(0xde)
+		binary.Write(buf, binary.BigEndian, uint16(len(keys))) // nolint:errcheck
+	}
+	for _, k := range keys {
+		encodeMsgpackString(buf, k)
+		encodeMsgpackString(buf, record[k])
+	}
+}
+
+func encodeMsgpackString(buf *bytes.Buffer, s string) {
+	l := len(s)
+	switch {
+	case l < 32:
+		buf.WriteByte(0xa0 | byte(l)) // fixstr
+	case l < 1<<8:
+		buf.WriteByte(0xd9) // str8
+		buf.WriteByte(byte(l))
+	case l < 1<<16:
+		// str16
+		buf.WriteByte(0xda)
+		binary.Write(buf, binary.BigEndian, uint16(l)) // nolint:errcheck
+	default:
+		// str32
+		buf.WriteByte(0xdb)
+		binary.Write(buf, binary.BigEndian, uint32(l)) // nolint:errcheck
+	}
+	buf.WriteString(s)
+}
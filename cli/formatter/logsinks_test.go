@@ -0,0 +1,166 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseLogSink(t *testing.T) {
+	tests := map[string]LogSinkConfig{
+		"file:/var/log/app.json":    {Type: FileLogSink, Path: "/var/log/app.json"},
+		"syslog":                    {Type: SyslogLogSink},
+		"syslog://localhost:514":    {Type: SyslogLogSink, Address: "localhost:514"},
+		"syslog+tcp://host:514":     {Type: SyslogLogSink, Address: "tcp://host:514"},
+		"https://logs.example.com":  {Type: HTTPLogSink, URL: "https://logs.example.com"},
+		"fluentd://localhost:24224": {Type: FluentdLogSink, Address: "localhost:24224"},
+	}
+	for value, expected := range tests {
+		config, err := ParseLogSink(value)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, config, expected)
+	}
+
+	_, err := ParseLogSink("kafka://localhost")
+	assert.Error(t, err, `unsupported log sink "kafka://localhost"`)
+}
+
+func TestFileLogSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logsinks")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+	path := filepath.Join(dir, "logs.json")
+
+	sink, err := NewLogSink(LogSinkConfig{Type: FileLogSink, Path: path})
+	assert.NilError(t, err)
+	sink.Log("web_1", "web", "hello")
+	assert.NilError(t, sink.Close())
+
+	b, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	var entry LogEntry
+	assert.NilError(t, json.Unmarshal(b, &entry))
+	assert.Equal(t, entry.Container, "web_1")
+	assert.Equal(t, entry.Message, "hello")
+}
+
+func TestHTTPLogSinkBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]LogEntry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []LogEntry
+		assert.Check(t, json.NewDecoder(r.Body).Decode(&batch))
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	sink, err := NewLogSink(LogSinkConfig{Type: HTTPLogSink, URL: server.URL, BatchSize: 2, FlushInterval: "1h"})
+	assert.NilError(t, err)
+	sink.Log("web_1", "web", "one")
+	sink.Err("web_1", "web", "two")
+	sink.Log("db_1", "db", "three")
+	assert.NilError(t, sink.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	var messages []string
+	for _, batch := range batches {
+		assert.Check(t, len(batch) <= 2)
+		for _, entry := range batch {
+			messages = append(messages, entry.Message)
+		}
+	}
+	assert.DeepEqual(t, messages, []string{"one", "two", "three"})
+}
+
+func TestEncodeFluentdMessage(t *testing.T) {
+	buf := bytes.Buffer{}
+	encodeFluentdMessage(&buf, "compose.web", time.Unix(1, 0), map[string]string{"log": "hi", "service": "web"})
+	assert.DeepEqual(t, buf.Bytes(), []byte{
+		0x93,
+		0xab, 'c', 'o', 'm', 'p', 'o', 's', 'e', '.', 'w', 'e', 'b',
+		0xce, 0, 0, 0, 1,
+		0x82,
+		0xa3, 'l', 'o', 'g', 0xa2, 'h', 'i',
+		0xa7, 's', 'e', 'r', 'v', 'i', 'c', 'e', 0xa3, 'w', 'e', 'b',
+	})
+}
+
+func TestFluentdLogSink(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer l.Close() // nolint:errcheck
+
+	received := make(chan []byte)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		b, _ := ioutil.ReadAll(conn)
+		received <- b
+	}()
+
+	sink, err := newFluentdLogSink(LogSinkConfig{Type: FluentdLogSink, Address: l.Addr().String()})
+	assert.NilError(t, err)
+	sink.Log("web_1", "web", "1970-01-01T00:00:01Z hi")
+	assert.NilError(t, sink.Close())
+
+	expected := bytes.Buffer{}
+	encodeFluentdMessage(&expected, "compose.web", time.Unix(1, 0), map[string]string{
+		"container_name": "web_1",
+		"service":        "web",
+		"source":         "stdout",
+		"log":            "hi",
+	})
+	assert.DeepEqual(t, <-received, expected.Bytes())
+}
+
+func TestFluentdLogSinkDropsWhenFull(t *testing.T) {
+	// sink isn't running, so queue is never consumed
+	sink := &fluentdLogSink{tag: "compose", queue: make(chan []byte, 1)}
+	sink.Log("web_1", "web", "one")
+	sink.Log("web_1", "web", "two")
+	assert.Equal(t, len(sink.queue), 1)
+	assert.Equal(t, sink.dropped, 1)
+}
+
+func TestHTTPLogSinkDropsOldest(t *testing.T) {
+	// sink isn't running, so batch is never sent
+	sink := &httpLogSink{batchSize: 1, full: make(chan struct{}, 1)}
+	for i := 0; i < httpMaxBatches+2; i++ {
+		sink.Log("web_1", "web", fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, len(sink.batch), httpMaxBatches)
+	assert.Equal(t, sink.batch[0].Message, "line 2")
+	assert.Equal(t, sink.dropped, 2)
+}
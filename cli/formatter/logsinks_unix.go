@@ -0,0 +1,60 @@
+// +build !windows
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+type syslogLogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogLogSink(config LogSinkConfig) (LogSink, error) {
+	var network, address string
+	if config.Address != "" {
+		network, address = splitSinkAddress(config.Address, "udp")
+	}
+	tag := config.Tag
+	if tag == "" {
+		tag = "compose"
+	}
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogLogSink{writer: w}, nil
+}
+
+func (s *syslogLogSink) Log(container, service, message string) {
+	s.writer.Info(fmt.Sprintf("%s %s", container, message)) // nolint:errcheck
+}
+
+func (s *syslogLogSink) Err(container, service, message string) {
+	s.writer.Err(fmt.Sprintf("%s %s", container, message)) // nolint:errcheck
+}
+
+func (s *syslogLogSink) Status(container, msg string) {}
+
+func (s *syslogLogSink) Register(container string) {}
+
+func (s *syslogLogSink) Close() error {
+	return s.writer.Close()
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/distribution/distribution/v3/reference"
 	cliconfig "github.com/docker/cli/cli/config"
 	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
 	"sigs.k8s.io/kustomize/kyaml/yaml/merge2"
 )
@@ -52,6 +53,9 @@ func (b *ecsAPIService) Kill(ctx context.Context, project *types.Project, option
 }
 
 func (b *ecsAPIService) Convert(ctx context.Context, project *types.Project, options compose.ConvertOptions) ([]byte, error) {
+	if options.FromRunning {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--from-running option is not supported on ECS")
+	}
 	err := b.resolveServiceImagesDigests(ctx, project)
 	if err != nil {
 		return nil, err
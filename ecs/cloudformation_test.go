@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/errdefs"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/awslabs/goformation/v4/cloudformation"
@@ -51,6 +52,11 @@ func TestSimpleConvert(t *testing.T) {
 	golden.Assert(t, result, expected)
 }
 
+func TestConvertFromRunning(t *testing.T) {
+	_, err := (&ecsAPIService{}).Convert(context.TODO(), &types.Project{Name: "test"}, compose.ConvertOptions{FromRunning: true})
+	assert.Check(t, errdefs.IsErrNotImplemented(err))
+}
+
 func TestLogging(t *testing.T) {
 	template := convertYaml(t, `
 services:
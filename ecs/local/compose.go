@@ -93,7 +93,7 @@ func (e ecsLocalSimulation) Convert(ctx context.Context, project *types.Project,
 	case "yaml":
 		return yaml.Marshal(config)
 	default:
-		return nil, fmt.Errorf("unsupported format %q", options)
+		return nil, fmt.Errorf("unsupported format %q", options.Format)
 	}
 
 }
@@ -215,3 +215,19 @@ func (e ecsLocalSimulation) Port(ctx context.Context, project string, service st
 func (e ecsLocalSimulation) Images(ctx context.Context, projectName string, options compose.ImagesOptions) ([]compose.ImageSummary, error) {
 	return nil, errdefs.ErrNotImplemented
 }
+
+func (e ecsLocalSimulation) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return e.compose.BackupVolumes(ctx, projectName, options)
+}
+
+func (e ecsLocalSimulation) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	return e.compose.RestoreVolumes(ctx, projectName, options)
+}
+
+func (e ecsLocalSimulation) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	return e.compose.PortForward(ctx, project, options)
+}
+
+func (e ecsLocalSimulation) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	return e.compose.Wait(ctx, projectName, options)
+}
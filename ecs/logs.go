@@ -27,6 +27,9 @@ func (b *ecsAPIService) Logs(ctx context.Context, projectName string, consumer c
 	if len(options.Services) > 0 {
 		consumer = utils.FilteredLogConsumer(consumer, options.Services)
 	}
-	err := b.aws.GetLogs(ctx, projectName, consumer.Log, options.Follow)
-	return err
+	consumer, err := utils.GrepLogConsumer(consumer, options.Grep, options.Timestamps)
+	if err != nil {
+		return err
+	}
+	return b.aws.GetLogs(ctx, projectName, consumer.Log, options)
 }
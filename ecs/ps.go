@@ -20,9 +20,15 @@ import (
 	"context"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/errdefs"
+
+	"github.com/pkg/errors"
 )
 
 func (b *ecsAPIService) Ps(ctx context.Context, projectName string, options compose.PsOptions) ([]compose.ContainerSummary, error) {
+	if len(options.Status) > 0 || len(options.Health) > 0 || len(options.Labels) > 0 {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--filter option is not supported on ECS")
+	}
 	cluster, err := b.aws.GetStackClusterID(ctx, projectName)
 	if err != nil {
 		return nil, err
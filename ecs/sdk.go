@@ -21,6 +21,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/docker/compose-cli/api/errdefs"
 	"github.com/docker/compose-cli/api/secrets"
 	"github.com/docker/compose-cli/internal"
+	"github.com/docker/compose-cli/utils"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
@@ -805,9 +808,32 @@ func (s sdk) DeleteSecret(ctx context.Context, id string, recover bool) error {
 	return err
 }
 
-func (s sdk) GetLogs(ctx context.Context, name string, consumer func(container string, service string, message string), follow bool) error {
+func (s sdk) GetLogs(ctx context.Context, name string, consumer func(container string, service string, message string), options compose.LogOptions) error {
 	logGroup := fmt.Sprintf("/docker-compose/%s", name)
-	var startTime int64
+	now := time.Now()
+	since, err := utils.ParseLogTime(options.Since, now)
+	if err != nil {
+		return err
+	}
+	until, err := utils.ParseLogTime(options.Until, now)
+	if err != nil {
+		return err
+	}
+	var startTime, endTime *int64
+	if !since.IsZero() {
+		startTime = aws.Int64(since.UnixNano() / int64(time.Millisecond))
+	}
+	if !until.IsZero() {
+		endTime = aws.Int64(until.UnixNano() / int64(time.Millisecond))
+	}
+	var filterPattern *string
+	if re, err := regexp.Compile(options.Grep); err == nil && options.Grep != "" {
+		// CloudWatch filter patterns only support terms, so we can only delegate filtering for literal patterns.
+		// Otherwise, all events are retrieved and filtered by the consumer.
+		if literal, complete := re.LiteralPrefix(); complete {
+			filterPattern = aws.String(strconv.Quote(literal))
+		}
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -817,9 +843,11 @@ func (s sdk) GetLogs(ctx context.Context, name string, consumer func(container s
 			var token *string
 			for hasMore {
 				events, err := s.CW.FilterLogEvents(&cloudwatchlogs.FilterLogEventsInput{
-					LogGroupName: aws.String(logGroup),
-					NextToken:    token,
-					StartTime:    aws.Int64(startTime),
+					LogGroupName:  aws.String(logGroup),
+					NextToken:     token,
+					StartTime:     startTime,
+					EndTime:       endTime,
+					FilterPattern: filterPattern,
 				})
 				if err != nil {
 					return err
@@ -833,11 +861,11 @@ func (s sdk) GetLogs(ctx context.Context, name string, consumer func(container s
 				for _, event := range events.Events {
 					p := strings.Split(aws.StringValue(event.LogStreamName), "/")
 					consumer(p[1], p[2], aws.StringValue(event.Message))
-					startTime = *event.IngestionTime
+					startTime = event.IngestionTime
 				}
 			}
 		}
-		if !follow {
+		if !options.Follow || (!until.IsZero() && time.Now().After(until)) {
 			return nil
 		}
 		time.Sleep(500 * time.Millisecond)
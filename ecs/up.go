@@ -144,3 +144,19 @@ func (b *ecsAPIService) up(ctx context.Context, project *types.Project, options
 	err = b.WaitStackCompletion(ctx, project.Name, operation, previousEvents...)
 	return err
 }
+
+func (b *ecsAPIService) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	return 0, errdefs.ErrNotImplemented
+}
@@ -22,28 +22,27 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/kube/resources"
 	"github.com/docker/compose-cli/utils"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
-	"k8s.io/client-go/transport/spdy"
 )
 
 // KubeClient API to access kube objects
 type KubeClient struct {
-	client    *kubernetes.Clientset
+	client    kubernetes.Interface
 	namespace string
 	config    *rest.Config
 	ioStreams genericclioptions.IOStreams
@@ -88,7 +87,7 @@ func (kc KubeClient) GetPod(ctx context.Context, projectName, serviceName string
 	var pod corev1.Pod
 	for _, p := range pods.Items {
 		service := p.Labels[compose.ServiceLabel]
-		if service == serviceName {
+		if service == serviceName && p.Labels[compose.OneoffLabel] != "True" {
 			pod = p
 			break
 		}
@@ -109,12 +108,6 @@ func (kc KubeClient) Exec(ctx context.Context, projectName string, opts compose.
 	container := &pod.Spec.Containers[0]
 	containerName := container.Name
 
-	req := kc.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(kc.namespace).
-		SubResource("exec")
-
 	option := &corev1.PodExecOptions{
 		Container: containerName,
 		Command:   opts.Command,
@@ -128,6 +121,22 @@ func (kc KubeClient) Exec(ctx context.Context, projectName string, opts compose.
 		option.Stdin = false
 	}
 
+	return kc.stream(pod.Name, "exec", option, remotecommand.StreamOptions{
+		Stdin:  opts.Reader,
+		Stdout: opts.Writer,
+		Stderr: opts.Writer,
+		Tty:    opts.Tty,
+	})
+}
+
+// stream connects to a pod exec or attach sub-resource through SPDY
+func (kc KubeClient) stream(podName string, subResource string, option runtime.Object, streamOptions remotecommand.StreamOptions) error {
+	req := kc.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(kc.namespace).
+		SubResource(subResource)
+
 	scheme := runtime.NewScheme()
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return fmt.Errorf("error adding to scheme: %v", err)
@@ -139,12 +148,7 @@ func (kc KubeClient) Exec(ctx context.Context, projectName string, opts compose.
 	if err != nil {
 		return err
 	}
-	return exec.Stream(remotecommand.StreamOptions{
-		Stdin:  opts.Reader,
-		Stdout: opts.Writer,
-		Stderr: opts.Writer,
-		Tty:    opts.Tty,
-	})
+	return exec.Stream(streamOptions)
 }
 
 // GetContainers get containers for a given compose project
@@ -161,6 +165,10 @@ func (kc KubeClient) GetContainers(ctx context.Context, projectName string, all
 	if err != nil {
 		return nil, err
 	}
+	ingresses, err := kc.getIngresses(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
 	services := map[string][]compose.PortPublisher{}
 	result := []compose.ContainerSummary{}
 	for _, pod := range pods.Items {
@@ -168,27 +176,9 @@ func (kc KubeClient) GetContainers(ctx context.Context, projectName string, all
 		serviceName := pod.GetObjectMeta().GetLabels()[compose.ServiceLabel]
 		ports, ok := services[serviceName]
 		if !ok {
-			s, err := kc.client.CoreV1().Services(kc.namespace).Get(ctx, serviceName, metav1.GetOptions{})
+			ports, err = kc.servicePublishers(ctx, serviceName, ingresses)
 			if err != nil {
-				if !strings.Contains(err.Error(), "not found") {
-					return nil, err
-				}
-				result = append(result, summary)
-				continue
-			}
-			ports = []compose.PortPublisher{}
-			if s != nil {
-				if s.Spec.Type == corev1.ServiceTypeLoadBalancer {
-					if len(s.Status.LoadBalancer.Ingress) > 0 {
-						port := compose.PortPublisher{URL: s.Status.LoadBalancer.Ingress[0].IP}
-						if len(s.Spec.Ports) > 0 {
-							port.URL = fmt.Sprintf("%s:%d", port.URL, s.Spec.Ports[0].Port)
-							port.TargetPort = s.Spec.Ports[0].TargetPort.IntValue()
-							port.Protocol = string(s.Spec.Ports[0].Protocol)
-						}
-						ports = append(ports, port)
-					}
-				}
+				return nil, err
 			}
 			services[serviceName] = ports
 		}
@@ -199,30 +189,97 @@ func (kc KubeClient) GetContainers(ctx context.Context, projectName string, all
 	return result, nil
 }
 
+// servicePublishers returns the addresses a service is published on, by its LoadBalancer Services and its Ingresses
+func (kc KubeClient) servicePublishers(ctx context.Context, serviceName string, ingresses []networkingv1.Ingress) ([]compose.PortPublisher, error) {
+	var ports []compose.PortPublisher
+	for _, name := range []string{serviceName, serviceName + resources.PublishedServiceSuffix} {
+		s, err := kc.client.CoreV1().Services(kc.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !strings.Contains(err.Error(), "not found") {
+				return nil, err
+			}
+			continue
+		}
+		if s.Spec.Type == corev1.ServiceTypeLoadBalancer && len(s.Status.LoadBalancer.Ingress) > 0 {
+			port := compose.PortPublisher{URL: s.Status.LoadBalancer.Ingress[0].IP}
+			if len(s.Spec.Ports) > 0 {
+				port.URL = fmt.Sprintf("%s:%d", port.URL, s.Spec.Ports[0].Port)
+				port.TargetPort = s.Spec.Ports[0].TargetPort.IntValue()
+				port.Protocol = string(s.Spec.Ports[0].Protocol)
+			}
+			ports = append(ports, port)
+		}
+		ports = append(ports, ingressPublishers(*s, ingresses)...)
+	}
+	return ports, nil
+}
+
 // GetLogs retrieves pod logs
-func (kc *KubeClient) GetLogs(ctx context.Context, projectName string, consumer compose.LogConsumer, follow bool) error {
+func (kc *KubeClient) GetLogs(ctx context.Context, projectName string, consumer compose.LogConsumer, options compose.LogOptions) error {
+	now := time.Now()
+	since, err := utils.ParseLogTime(options.Since, now)
+	if err != nil {
+		return err
+	}
+	until, err := utils.ParseLogTime(options.Until, now)
+	if err != nil {
+		return err
+	}
 	pods, err := kc.client.CoreV1().Pods(kc.namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
 	})
 	if err != nil {
 		return err
 	}
+	logOptions := &corev1.PodLogOptions{
+		Follow: options.Follow,
+		// kubernetes has no equivalent for `until`, so we need timestamps to filter out log lines
+		Timestamps: options.Timestamps || !until.IsZero(),
+	}
+	if !since.IsZero() {
+		sinceTime := metav1.NewTime(since)
+		logOptions.SinceTime = &sinceTime
+	}
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, pod := range pods.Items {
-		request := kc.client.CoreV1().Pods(kc.namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Follow: follow})
+		pod := pod
+		request := kc.client.CoreV1().Pods(kc.namespace).GetLogs(pod.Name, logOptions)
 		service := pod.Labels[compose.ServiceLabel]
+		// streams are closed once past `until`, so following logs ends
+		streamCtx, cancel := context.WithCancel(ctx)
+		if !until.IsZero() && options.Follow {
+			stop := time.AfterFunc(time.Until(until), cancel)
+			defer stop.Stop()
+		}
 		w := utils.GetWriter(func(line string) {
+			if !until.IsZero() {
+				timestamp, message, ok := splitLogTimestamp(line)
+				if ok && timestamp.After(until) {
+					cancel()
+					return
+				}
+				if !options.Timestamps {
+					line = message
+				}
+			}
 			consumer.Log(pod.Name, service, line)
 		})
 
 		eg.Go(func() error {
-			r, err := request.Stream(ctx)
+			defer cancel()
+			r, err := request.Stream(streamCtx)
 			if err != nil {
+				if streamCtx.Err() != nil && ctx.Err() == nil {
+					return nil
+				}
 				return err
 			}
 
 			defer r.Close() // nolint errcheck
 			_, err = io.Copy(w, r)
+			if err != nil && streamCtx.Err() != nil && ctx.Err() == nil {
+				return nil
+			}
 			return err
 		})
 	}
@@ -276,42 +333,3 @@ func (kc KubeClient) WaitForPodState(ctx context.Context, opts WaitForStatusOpti
 	}
 	return nil
 }
-
-//MapPortsToLocalhost runs a port-forwarder daemon process
-func (kc KubeClient) MapPortsToLocalhost(ctx context.Context, opts PortMappingOptions) error {
-	stopChannel := make(chan struct{}, 1)
-	readyChannel := make(chan struct{})
-
-	eg, ctx := errgroup.WithContext(ctx)
-	for serviceName, servicePorts := range opts.Services {
-		serviceName := serviceName
-		servicePorts := servicePorts
-		pod, err := kc.GetPod(ctx, opts.ProjectName, serviceName)
-		if err != nil {
-			return err
-		}
-		eg.Go(func() error {
-			ports := []string{}
-			for _, p := range servicePorts {
-				ports = append(ports, fmt.Sprintf("%d:%d", p.PublishedPort, p.TargetPort))
-			}
-
-			req := kc.client.CoreV1().RESTClient().Post().
-				Resource("pods").
-				Name(pod.Name).
-				Namespace(kc.namespace).
-				SubResource("portforward")
-			transport, upgrader, err := spdy.RoundTripperFor(kc.config)
-			if err != nil {
-				return err
-			}
-			dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
-			fw, err := portforward.New(dialer, ports, stopChannel, readyChannel, os.Stdout, os.Stderr)
-			if err != nil {
-				return err
-			}
-			return fw.ForwardPorts()
-		})
-	}
-	return eg.Wait()
-}
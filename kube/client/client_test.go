@@ -20,6 +20,7 @@ package client
 
 import (
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,3 +55,34 @@ func TestPodToContainerSummary(t *testing.T) {
 	}
 	assert.DeepEqual(t, container, expected)
 }
+
+func TestSplitLogTimestamp(t *testing.T) {
+	timestamp, message, ok := splitLogTimestamp("2021-06-10T14:35:33.123456789Z hello world")
+	assert.Assert(t, ok)
+	assert.Equal(t, message, "hello world")
+	assert.Assert(t, timestamp.Equal(time.Date(2021, 6, 10, 14, 35, 33, 123456789, time.UTC)))
+
+	_, message, ok = splitLogTimestamp("hello world")
+	assert.Assert(t, !ok)
+	assert.Equal(t, message, "hello world")
+}
+
+func TestCheckPodsStateRunning(t *testing.T) {
+	reached, _, err := checkPodsState([]string{"service1"}, nil, compose.RUNNING)
+	assert.NilError(t, err)
+	assert.Assert(t, !reached, "no pod must not be considered as running")
+
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "c1-123", Labels: map[string]string{compose.ServiceLabel: "service1"}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	reached, _, err = checkPodsState([]string{"service1"}, []v1.Pod{pod}, compose.RUNNING)
+	assert.NilError(t, err)
+	assert.Assert(t, reached)
+
+	// a running one-off pod doesn't tell the service is running
+	pod.Labels[compose.OneoffLabel] = "True"
+	reached, _, err = checkPodsState([]string{"service1"}, []v1.Pod{pod}, compose.RUNNING)
+	assert.NilError(t, err)
+	assert.Assert(t, !reached)
+}
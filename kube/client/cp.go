@@ -0,0 +1,159 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// PathStat describes a path in a pod container
+type PathStat struct {
+	Name       string
+	Exists     bool
+	IsDir      bool
+	LinkTarget string
+}
+
+// statScript reports path type, and link target for symbolic links
+const statScript = `if [ -L "$1" ]; then echo link; readlink "$1"; elif [ -d "$1" ]; then echo dir; elif [ -e "$1" ]; then echo file; else echo none; fi`
+
+// GetServicePods returns the pods of a service, sorted by name, ignoring one-off pods
+func (kc KubeClient) GetServicePods(ctx context.Context, projectName, serviceName string) ([]corev1.Pod, error) {
+	pods, err := kc.client.CoreV1().Pods(kc.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", compose.ProjectLabel, projectName, compose.ServiceLabel, serviceName),
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Labels[compose.OneoffLabel] == "True" {
+			continue
+		}
+		result = append(result, pod)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// ExecInPod runs a command in the main container of a pod
+func (kc KubeClient) ExecInPod(pod corev1.Pod, command []string, stdin io.Reader, stdout io.Writer) error {
+	if len(pod.Spec.Containers) == 0 {
+		return fmt.Errorf("no containers running in pod %s", pod.Name)
+	}
+	stderr := bytes.Buffer{}
+	err := kc.stream(pod.Name, "exec", &corev1.PodExecOptions{
+		Container: pod.Spec.Containers[0].Name,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    true,
+	}, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return err
+}
+
+// StatPath inspects a path in a pod container
+func (kc KubeClient) StatPath(pod corev1.Pod, p string) (PathStat, error) {
+	stdout := bytes.Buffer{}
+	if err := kc.ExecInPod(pod, []string{"sh", "-c", statScript, "stat", p}, nil, &stdout); err != nil {
+		return PathStat{}, err
+	}
+	return parsePathStat(p, stdout.String())
+}
+
+func parsePathStat(p string, output string) (PathStat, error) {
+	lines := strings.SplitN(strings.TrimSpace(output), "\n", 2)
+	stat := PathStat{Name: path.Base(p), Exists: true}
+	switch lines[0] {
+	case "none":
+		stat.Exists = false
+	case "dir":
+		stat.IsDir = true
+	case "file":
+	case "link":
+		if len(lines) < 2 {
+			return PathStat{}, fmt.Errorf("failed to read link %s", p)
+		}
+		stat.LinkTarget = strings.TrimSpace(lines[1])
+	default:
+		return PathStat{}, fmt.Errorf("failed to stat %s: %s", p, output)
+	}
+	return stat, nil
+}
+
+// CopyFromPod returns a tar archive of a path in a pod container, with the path base name as root entry,
+// like docker engine does
+func (kc KubeClient) CopyFromPod(pod corev1.Pod, srcPath string) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		err := kc.ExecInPod(pod, copyFromPodCommand(srcPath), nil, w)
+		w.CloseWithError(err) // nolint:errcheck
+	}()
+	if path.Clean(srcPath) == "/" {
+		// docker engine names root content relative to the "./" root entry
+		return utils.TrimArchiveDotPrefix(r)
+	}
+	return r
+}
+
+func copyFromPodCommand(srcPath string) []string {
+	if path.Clean(srcPath) == "/" {
+		return []string{"tar", "cf", "-", "-C", "/", "."}
+	}
+	dir, base := path.Split(strings.TrimSuffix(srcPath, "/"))
+	if dir == "" {
+		dir = "."
+	}
+	if base == "" {
+		base = "."
+	}
+	return []string{"tar", "cf", "-", "-C", dir, base}
+}
+
+// CopyToPod extracts a tar archive into a directory of a pod container
+func (kc KubeClient) CopyToPod(pod corev1.Pod, dstDir string, content io.Reader, copyUIDGID bool) error {
+	command := []string{"tar", "xf", "-", "-C", dstDir}
+	if !copyUIDGID {
+		command = append(command, "--no-same-owner")
+	}
+	return kc.ExecInPod(pod, command, content, nil)
+}
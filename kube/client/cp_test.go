@@ -0,0 +1,81 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestParsePathStat(t *testing.T) {
+	stat, err := parsePathStat("/var/log/", "dir\n")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stat, PathStat{Name: "log", Exists: true, IsDir: true})
+
+	stat, err = parsePathStat("/etc/config", "link\n/config/app.conf\n")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stat, PathStat{Name: "config", Exists: true, LinkTarget: "/config/app.conf"})
+
+	stat, err = parsePathStat("/missing", "none\n")
+	assert.NilError(t, err)
+	assert.Assert(t, !stat.Exists)
+
+	_, err = parsePathStat("/missing", "sh: not found")
+	assert.ErrorContains(t, err, "failed to stat /missing")
+}
+
+func TestCopyFromPodCommand(t *testing.T) {
+	assert.DeepEqual(t, copyFromPodCommand("/var/log/"), []string{"tar", "cf", "-", "-C", "/var/", "log"})
+	assert.DeepEqual(t, copyFromPodCommand("app.conf"), []string{"tar", "cf", "-", "-C", ".", "app.conf"})
+	assert.DeepEqual(t, copyFromPodCommand("/"), []string{"tar", "cf", "-", "-C", "/", "."})
+	assert.DeepEqual(t, copyFromPodCommand("//"), []string{"tar", "cf", "-", "-C", "/", "."})
+}
+
+func TestGetServicePods(t *testing.T) {
+	pod := func(name string, oneoff string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					compose.ProjectLabel: "myproject",
+					compose.ServiceLabel: "web",
+					compose.OneoffLabel:  oneoff,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(pod("web-b", "False"), pod("web-a", "False"), pod("web-run-x", "True")),
+		namespace: "default",
+	}
+	pods, err := kc.GetServicePods(context.Background(), "myproject", "web")
+	assert.NilError(t, err)
+	assert.Equal(t, len(pods), 2)
+	assert.Equal(t, pods[0].Name, "web-a")
+	assert.Equal(t, pods[1].Name, "web-b")
+}
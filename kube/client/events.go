@@ -0,0 +1,184 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// eventStatuses maps kubelet event reasons to the equivalent docker engine container event status
+var eventStatuses = map[string]string{
+	"Created":    "create",
+	"Started":    "start",
+	"Killing":    "kill",
+	"BackOff":    "restart",
+	"Pulling":    "pull",
+	"OOMKilling": "oom",
+	"Unhealthy":  compose.EventHealthStatus,
+}
+
+// WatchEventsOptions select the events to watch
+type WatchEventsOptions struct {
+	ProjectName string
+	Since       time.Time
+	Until       time.Time
+	Consumer    func(event compose.Event) error
+}
+
+// WatchEvents streams Kubernetes events for project pods
+func (kc KubeClient) WatchEvents(ctx context.Context, opts WatchEventsOptions) error {
+	listOptions := metav1.ListOptions{FieldSelector: "involvedObject.kind=Pod"}
+	events, err := kc.client.CoreV1().Events(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	services := podServices{}
+	if err := services.refresh(ctx, kc, opts.ProjectName); err != nil {
+		return err
+	}
+
+	if !opts.Since.IsZero() {
+		for _, event := range events.Items {
+			if err := kc.consumeEvent(ctx, event, services, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	listOptions.ResourceVersion = events.ResourceVersion
+	watcher, err := kc.client.CoreV1().Events(kc.namespace).Watch(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	var untilC <-chan time.Time
+	if !opts.Until.IsZero() {
+		untilC = time.After(time.Until(opts.Until))
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-untilC:
+			return nil
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			if e.Type != watch.Added && e.Type != watch.Modified {
+				continue
+			}
+			event, ok := e.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			if err := kc.consumeEvent(ctx, *event, services, opts); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (kc KubeClient) consumeEvent(ctx context.Context, event corev1.Event, services podServices, opts WatchEventsOptions) error {
+	timestamp := eventTimestamp(event)
+	if !opts.Since.IsZero() && timestamp.Before(opts.Since) {
+		return nil
+	}
+	if !opts.Until.IsZero() && timestamp.After(opts.Until) {
+		return nil
+	}
+	pod := event.InvolvedObject.Name
+	service, ok := services[pod]
+	if !ok {
+		// pod created after we started watching
+		if err := services.refresh(ctx, kc, opts.ProjectName); err != nil {
+			return err
+		}
+		service, ok = services[pod]
+		if !ok {
+			services[pod] = ""
+		}
+	}
+	if service == "" {
+		return nil
+	}
+	return opts.Consumer(toComposeEvent(event, service))
+}
+
+// podServices maps pod names to compose service names, empty for pods not belonging to the project
+type podServices map[string]string
+
+func (p podServices) refresh(ctx context.Context, kc KubeClient, projectName string) error {
+	pods, err := kc.client.CoreV1().Pods(kc.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	})
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		p[pod.Name] = pod.Labels[compose.ServiceLabel]
+	}
+	return nil
+}
+
+func eventTimestamp(event corev1.Event) time.Time {
+	switch {
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	default:
+		return event.FirstTimestamp.Time
+	}
+}
+
+func toComposeEvent(event corev1.Event, service string) compose.Event {
+	status, ok := eventStatuses[event.Reason]
+	if !ok {
+		status = strings.ToLower(event.Reason)
+	}
+	attributes := map[string]string{
+		"reason":  event.Reason,
+		"message": event.Message,
+		"type":    event.Type,
+	}
+	if event.InvolvedObject.FieldPath != "" {
+		attributes["fieldPath"] = event.InvolvedObject.FieldPath
+	}
+	if status == compose.EventHealthStatus {
+		attributes[compose.EventHealthStatus] = "unhealthy"
+	}
+	return compose.Event{
+		Timestamp:  eventTimestamp(event),
+		Service:    service,
+		Container:  event.InvolvedObject.Name,
+		Status:     status,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,94 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestWatchEventsReplay(t *testing.T) {
+	timestamp := time.Date(2021, 6, 10, 14, 35, 33, 0, time.UTC)
+	podEvent := func(name, pod, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod},
+			Reason:         reason,
+			Type:           "Normal",
+			LastTimestamp:  metav1.NewTime(timestamp),
+		}
+	}
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web-1234",
+					Namespace: "default",
+					Labels: map[string]string{
+						compose.ProjectLabel: "myproject",
+						compose.ServiceLabel: "web",
+					},
+				},
+			},
+			podEvent("e1", "web-1234", "Started"),
+			podEvent("e2", "other-5678", "Started"),
+		),
+		namespace: "default",
+	}
+
+	var events []compose.Event
+	err := kc.WatchEvents(context.Background(), WatchEventsOptions{
+		ProjectName: "myproject",
+		Since:       timestamp.Add(-time.Minute),
+		Until:       timestamp.Add(time.Minute),
+		Consumer: func(event compose.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].Service, "web")
+	assert.Equal(t, events[0].Container, "web-1234")
+	assert.Equal(t, events[0].Status, "start")
+	assert.Assert(t, events[0].Timestamp.Equal(timestamp))
+}
+
+func TestToComposeEvent(t *testing.T) {
+	event := toComposeEvent(corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1234"},
+		Reason:         "Unhealthy",
+		Message:        "Liveness probe failed",
+		Type:           "Warning",
+	}, "web")
+	assert.Equal(t, event.Status, compose.EventHealthStatus)
+	assert.Equal(t, event.Attributes[compose.EventHealthStatus], "unhealthy")
+	assert.Equal(t, event.Attributes["message"], "Liveness probe failed")
+
+	event = toComposeEvent(corev1.Event{Reason: "FailedMount"}, "web")
+	assert.Equal(t, event.Status, "failedmount")
+}
@@ -0,0 +1,77 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// Images lists the images used by project pods, as reported by pod container statuses
+func (kc KubeClient) Images(ctx context.Context, projectName string, services []string) ([]compose.ImageSummary, error) {
+	pods, err := kc.getProjectPods(ctx, projectName, services, false)
+	if err != nil {
+		return nil, err
+	}
+	var summary []compose.ImageSummary
+	for _, pod := range pods {
+		summary = append(summary, podImages(pod)...)
+	}
+	return summary, nil
+}
+
+func podImages(pod corev1.Pod) []compose.ImageSummary {
+	var images []compose.ImageSummary
+	for _, status := range pod.Status.ContainerStatuses {
+		repository, tag := splitImageReference(status.Image)
+		images = append(images, compose.ImageSummary{
+			ID:            imageDigest(status.ImageID),
+			ContainerName: pod.Name,
+			Repository:    repository,
+			Tag:           tag,
+		})
+	}
+	return images
+}
+
+// splitImageReference splits an image reference into repository and tag, or digest
+func splitImageReference(image string) (string, string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+// imageDigest extracts the digest from a container status image ID, i.e. `docker-pullable://nginx@sha256:xx`
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		return imageID[i+3:]
+	}
+	return imageID
+}
@@ -0,0 +1,47 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodImages(t *testing.T) {
+	images := podImages(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1234"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Image: "nginx:1.21", ImageID: "docker-pullable://nginx@sha256:abcd"},
+				{Image: "localhost:5000/sidecar", ImageID: "sha256:1234"},
+			},
+		},
+	})
+	assert.Equal(t, len(images), 2)
+	assert.Equal(t, images[0].ContainerName, "web-1234")
+	assert.Equal(t, images[0].Repository, "nginx")
+	assert.Equal(t, images[0].Tag, "1.21")
+	assert.Equal(t, images[0].ID, "sha256:abcd")
+	assert.Equal(t, images[1].Repository, "localhost:5000/sidecar")
+	assert.Equal(t, images[1].Tag, "latest")
+	assert.Equal(t, images[1].ID, "sha256:1234")
+}
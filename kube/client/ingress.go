@@ -0,0 +1,112 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// getIngresses lists project Ingresses. As Ingresses are optional, lacking permission to list them is not an error.
+func (kc KubeClient) getIngresses(ctx context.Context, projectName string) ([]networkingv1.Ingress, error) {
+	ingresses, err := kc.client.NetworkingV1().Ingresses(kc.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	})
+	if errors.IsForbidden(err) || errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ingresses.Items, nil
+}
+
+// ingressPublishers returns the URLs Ingresses route to service, once the Ingress host or address is known
+func ingressPublishers(service corev1.Service, ingresses []networkingv1.Ingress) []compose.PortPublisher {
+	var publishers []compose.PortPublisher
+	for _, ingress := range ingresses {
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			host := rule.Host
+			if host == "" {
+				host = ingressAddress(ingress)
+			}
+			if host == "" {
+				continue
+			}
+			scheme := "http"
+			if ingressTLS(ingress, rule.Host) {
+				scheme = "https"
+			}
+			for _, path := range rule.HTTP.Paths {
+				backend := path.Backend.Service
+				if backend == nil || backend.Name != service.Name {
+					continue
+				}
+				for _, p := range service.Spec.Ports {
+					if !(backend.Port.Number != 0 && p.Port == backend.Port.Number) && !(backend.Port.Name != "" && p.Name == backend.Port.Name) {
+						continue
+					}
+					publishers = append(publishers, compose.PortPublisher{
+						URL:        fmt.Sprintf("%s://%s%s", scheme, host, path.Path),
+						TargetPort: p.TargetPort.IntValue(),
+						Protocol:   string(p.Protocol),
+					})
+					break
+				}
+			}
+		}
+	}
+	return publishers
+}
+
+func ingressAddress(ingress networkingv1.Ingress) string {
+	for _, address := range ingress.Status.LoadBalancer.Ingress {
+		if address.Hostname != "" {
+			return address.Hostname
+		}
+		if address.IP != "" {
+			return address.IP
+		}
+	}
+	return ""
+}
+
+func ingressTLS(ingress networkingv1.Ingress, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			return true
+		}
+		for _, h := range tls.Hosts {
+			if h == host {
+				return true
+			}
+		}
+	}
+	return false
+}
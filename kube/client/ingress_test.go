@@ -0,0 +1,94 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func testIngress(host string, tls bool, address string) *networkingv1.Ingress {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: testLabels("myproject", "web")},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path: "/app",
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "web",
+									Port: networkingv1.ServiceBackendPort{Number: 8080},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if tls {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: "web-tls"}}
+	}
+	if address != "" {
+		ingress.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: address}}
+	}
+	return ingress
+}
+
+func TestIngressPublishers(t *testing.T) {
+	service := *testService("web", corev1.ServiceTypeClusterIP)
+
+	publishers := ingressPublishers(service, []networkingv1.Ingress{*testIngress("web.example.com", true, "")})
+	assert.DeepEqual(t, publishers, []compose.PortPublisher{{URL: "https://web.example.com/app", TargetPort: 80, Protocol: "TCP"}})
+
+	publishers = ingressPublishers(service, []networkingv1.Ingress{*testIngress("", false, "10.0.0.2")})
+	assert.DeepEqual(t, publishers, []compose.PortPublisher{{URL: "http://10.0.0.2/app", TargetPort: 80, Protocol: "TCP"}})
+
+	// no host and no address assigned yet
+	publishers = ingressPublishers(service, []networkingv1.Ingress{*testIngress("", false, "")})
+	assert.Equal(t, len(publishers), 0)
+}
+
+func TestGetContainersIngressPublishers(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: testLabels("myproject", "web")}},
+			testService("web", corev1.ServiceTypeClusterIP),
+			testIngress("web.example.com", false, ""),
+		),
+		namespace: "default",
+	}
+
+	containers, err := kc.GetContainers(ctx, "myproject", true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(containers), 1)
+	assert.DeepEqual(t, containers[0].Publishers, []compose.PortPublisher{{URL: "http://web.example.com/app", TargetPort: 80, Protocol: "TCP"}})
+}
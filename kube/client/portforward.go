@@ -0,0 +1,281 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/kube/resources"
+)
+
+// forwardPodTimeout is how long a forwarded connection waits for a service pod to be ready, i.e. during a restart
+const forwardPodTimeout = 30 * time.Second
+
+// GetPublishedPort returns the external address and port a service target port is published on by its LoadBalancer
+// Services
+func (kc KubeClient) GetPublishedPort(ctx context.Context, projectName string, serviceName string, port int, protocol string) (string, int, error) {
+	service, err := kc.client.CoreV1().Services(kc.namespace).Get(ctx, serviceName, metav1.GetOptions{})
+	if errors.IsNotFound(err) || (err == nil && service.Spec.Selector[compose.ProjectLabel] != projectName) {
+		return "", 0, fmt.Errorf("no such service: %s", serviceName)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	services := []*corev1.Service{service}
+	// ports an Ingress doesn't route are published by a dedicated LoadBalancer Service
+	published, err := kc.client.CoreV1().Services(kc.namespace).Get(ctx, serviceName+resources.PublishedServiceSuffix, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return "", 0, err
+	}
+	if err == nil {
+		services = append(services, published)
+	}
+	for _, service := range services {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		for _, p := range service.Spec.Ports {
+			if p.TargetPort.IntValue() != port || !strings.EqualFold(string(p.Protocol), protocol) {
+				continue
+			}
+			for _, ingress := range service.Status.LoadBalancer.Ingress {
+				if ingress.IP != "" {
+					return ingress.IP, int(p.Port), nil
+				}
+				if ingress.Hostname != "" {
+					return ingress.Hostname, int(p.Port), nil
+				}
+			}
+			return "", 0, fmt.Errorf("service %s has no external address yet", service.Name)
+		}
+	}
+	return "", 0, fmt.Errorf("port %d/%s is not published by service %s", port, protocol, serviceName)
+}
+
+// ForwardPorts forwards local ports to service pods until ctx is done. Pods are resolved for every connection, so
+// forwarding survives pod restarts and connections are balanced across service replicas.
+func (kc KubeClient) ForwardPorts(ctx context.Context, opts PortMappingOptions) error {
+	address := opts.Address
+	if address == "" {
+		address = "127.0.0.1"
+	}
+	log := opts.Log
+	if log == nil {
+		log = func(string) {}
+	}
+
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close() // nolint:errcheck
+		}
+	}()
+	eg, ctx := errgroup.WithContext(ctx)
+	for serviceName, servicePorts := range opts.Services {
+		balancer := &podBalancer{kc: kc, projectName: opts.ProjectName, service: serviceName}
+		for _, p := range servicePorts {
+			listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(int(p.PublishedPort))))
+			if err != nil {
+				return err
+			}
+			listeners = append(listeners, listener)
+			log(fmt.Sprintf("Forwarding %s -> %s:%d", listener.Addr(), serviceName, p.TargetPort))
+
+			target := int(p.TargetPort)
+			eg.Go(func() error {
+				return kc.acceptConnections(ctx, listener, balancer, target, log)
+			})
+		}
+	}
+	eg.Go(func() error {
+		<-ctx.Done()
+		for _, l := range listeners {
+			l.Close() // nolint:errcheck
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func (kc KubeClient) acceptConnections(ctx context.Context, listener net.Listener, balancer *podBalancer, port int, log func(string)) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close() // nolint:errcheck
+			pod, err := balancer.next(ctx)
+			if err != nil {
+				log(err.Error())
+				return
+			}
+			if err := kc.forwardConnection(ctx, conn, pod, port); err != nil {
+				log(fmt.Sprintf("%s: forwarding to pod %s failed: %s", balancer.service, pod, err))
+			}
+		}()
+	}
+}
+
+// forwardConnection streams a local connection to a pod port over a dedicated port-forward session
+func (kc KubeClient) forwardConnection(ctx context.Context, conn net.Conn, pod string, port int) error {
+	req := kc.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(kc.namespace).
+		SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(kc.config)
+	if err != nil {
+		return err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return err
+	}
+	defer streamConn.Close() // nolint:errcheck
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return err
+	}
+	// we never write to the error stream
+	errorStream.Close() // nolint:errcheck
+	errCh := make(chan error, 1)
+	go func() {
+		message, err := ioutil.ReadAll(errorStream)
+		switch {
+		case err != nil:
+			errCh <- err
+		case len(message) > 0:
+			errCh <- fmt.Errorf("%s", message)
+		}
+		close(errCh)
+	}()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return err
+	}
+	remoteDone := make(chan struct{})
+	localDone := make(chan struct{})
+	go func() {
+		io.Copy(conn, dataStream) // nolint:errcheck
+		close(remoteDone)
+	}()
+	go func() {
+		defer dataStream.Close()  // nolint:errcheck
+		io.Copy(dataStream, conn) // nolint:errcheck
+		close(localDone)
+	}()
+
+	select {
+	case <-remoteDone:
+	case <-localDone:
+	case <-ctx.Done():
+		return nil
+	}
+	return <-errCh
+}
+
+// podBalancer picks a ready service pod for every forwarded connection, round-robin
+type podBalancer struct {
+	kc          KubeClient
+	projectName string
+	service     string
+	mu          sync.Mutex
+	count       int
+}
+
+func (b *podBalancer) next(ctx context.Context) (string, error) {
+	deadline := time.Now().Add(forwardPodTimeout)
+	for {
+		pods, err := b.readyPods(ctx)
+		if err != nil {
+			return "", err
+		}
+		if len(pods) > 0 {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			pod := pods[b.count%len(pods)]
+			b.count++
+			return pod, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("%s: no pod ready to forward to", b.service)
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (b *podBalancer) readyPods(ctx context.Context) ([]string, error) {
+	pods, err := b.kc.GetServicePods(ctx, b.projectName, b.service)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			continue
+		}
+		names = append(names, pod.Name)
+	}
+	return names, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func testService(name string, serviceType corev1.ServiceType, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Type:     serviceType,
+			Selector: testLabels("myproject", name),
+			Ports: []corev1.ServicePort{
+				{Port: 8080, TargetPort: intstr.FromInt(80), Protocol: corev1.ProtocolTCP},
+			},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress},
+		},
+	}
+}
+
+func TestGetPublishedPort(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			testService("web", corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{IP: "10.0.0.1"}),
+			testService("api", corev1.ServiceTypeLoadBalancer),
+			testService("db", corev1.ServiceTypeClusterIP),
+		),
+		namespace: "default",
+	}
+
+	host, port, err := kc.GetPublishedPort(ctx, "myproject", "web", 80, "tcp")
+	assert.NilError(t, err)
+	assert.Equal(t, host, "10.0.0.1")
+	assert.Equal(t, port, 8080)
+
+	_, _, err = kc.GetPublishedPort(ctx, "myproject", "web", 80, "udp")
+	assert.Error(t, err, "port 80/udp is not published by service web")
+	_, _, err = kc.GetPublishedPort(ctx, "myproject", "api", 80, "tcp")
+	assert.Error(t, err, "service api has no external address yet")
+	_, _, err = kc.GetPublishedPort(ctx, "myproject", "db", 80, "tcp")
+	assert.Error(t, err, "port 80/tcp is not published by service db")
+	_, _, err = kc.GetPublishedPort(ctx, "otherproject", "web", 80, "tcp")
+	assert.Error(t, err, "no such service: web")
+	_, _, err = kc.GetPublishedPort(ctx, "myproject", "cache", 80, "tcp")
+	assert.Error(t, err, "no such service: cache")
+}
+
+func TestGetPublishedPortNotRoutedByIngress(t *testing.T) {
+	ctx := context.Background()
+	published := testService("web-published", corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{IP: "10.0.0.2"})
+	published.Spec.Selector = testLabels("myproject", "web")
+	published.Spec.Ports = []corev1.ServicePort{{Port: 8443, TargetPort: intstr.FromInt(443), Protocol: corev1.ProtocolTCP}}
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(testService("web", corev1.ServiceTypeClusterIP), published),
+		namespace: "default",
+	}
+
+	host, port, err := kc.GetPublishedPort(ctx, "myproject", "web", 443, "tcp")
+	assert.NilError(t, err)
+	assert.Equal(t, host, "10.0.0.2")
+	assert.Equal(t, port, 8443)
+
+	// routed by the Ingress
+	_, _, err = kc.GetPublishedPort(ctx, "myproject", "web", 80, "tcp")
+	assert.Error(t, err, "port 80/tcp is not published by service web")
+}
+
+func TestPodBalancer(t *testing.T) {
+	ctx := context.Background()
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: testLabels("myproject", "web")},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	oneOff := pod("web-run", corev1.ConditionTrue)
+	oneOff.Labels[compose.OneoffLabel] = "True"
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(pod("web-1", corev1.ConditionTrue), pod("web-2", corev1.ConditionFalse), pod("web-3", corev1.ConditionTrue), oneOff),
+		namespace: "default",
+	}
+
+	balancer := &podBalancer{kc: kc, projectName: "myproject", service: "web"}
+	var picked []string
+	for i := 0; i < 4; i++ {
+		p, err := balancer.next(ctx)
+		assert.NilError(t, err)
+		picked = append(picked, p)
+	}
+	assert.DeepEqual(t, picked, []string{"web-1", "web-3", "web-1", "web-3"})
+}
@@ -0,0 +1,131 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// DeleteVolumeClaims deletes the project PersistentVolumeClaims, which are kept by Helm on uninstall
+func (kc KubeClient) DeleteVolumeClaims(ctx context.Context, projectName string) ([]string, error) {
+	claims, err := kc.client.CoreV1().PersistentVolumeClaims(kc.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, claim := range claims.Items {
+		if err := kc.client.CoreV1().PersistentVolumeClaims(kc.namespace).Delete(ctx, claim.Name, metav1.DeleteOptions{}); err != nil {
+			return names, err
+		}
+		names = append(names, claim.Name)
+	}
+	return names, nil
+}
+
+// KillPods sends signal to the main container of project service pods. As Kubernetes has no API to signal a
+// container, SIGKILL deletes the pods without grace period while other signals are sent by running `kill` in the
+// container, which requires the image to provide it. Pods managed by a controller get replaced.
+func (kc KubeClient) KillPods(ctx context.Context, projectName string, services []string, signal string) ([]string, error) {
+	pods, err := kc.getProjectPods(ctx, projectName, services, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var names []string
+	for _, pod := range pods {
+		if err := kc.killPod(ctx, pod, signal); err != nil {
+			return names, err
+		}
+		names = append(names, pod.Name)
+	}
+	return names, nil
+}
+
+func (kc KubeClient) killPod(ctx context.Context, pod corev1.Pod, signal string) error {
+	signal = strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+	if signal == "" || signal == "KILL" || signal == "9" {
+		var gracePeriod int64
+		return kc.client.CoreV1().Pods(kc.namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: &gracePeriod,
+		})
+	}
+	return kc.ExecInPod(pod, []string{"kill", "-" + signal, "1"}, nil, nil)
+}
+
+// GetCompletedOneOffJobs lists the one-off Jobs run for project services which have completed
+func (kc KubeClient) GetCompletedOneOffJobs(ctx context.Context, projectName string, services []string) ([]string, error) {
+	return kc.getOneOffJobs(ctx, projectName, services, true)
+}
+
+// GetOneOffJobs lists all the one-off Jobs run for project services
+func (kc KubeClient) GetOneOffJobs(ctx context.Context, projectName string) ([]string, error) {
+	return kc.getOneOffJobs(ctx, projectName, nil, false)
+}
+
+func (kc KubeClient) getOneOffJobs(ctx context.Context, projectName string, services []string, completedOnly bool) ([]string, error) {
+	jobs, err := kc.client.BatchV1().Jobs(kc.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=True", compose.ProjectLabel, projectName, compose.OneoffLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, job := range jobs.Items {
+		if len(services) > 0 && !utils.StringContains(services, job.Labels[compose.ServiceLabel]) {
+			continue
+		}
+		if completedOnly && !jobCompleted(job) {
+			continue
+		}
+		names = append(names, job.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func jobCompleted(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveOneOffJob deletes a one-off Job along with its pod
+func (kc KubeClient) RemoveOneOffJob(ctx context.Context, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	return kc.client.BatchV1().Jobs(kc.namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+}
@@ -0,0 +1,114 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func testLabels(project string, service string) map[string]string {
+	return map[string]string{
+		compose.ProjectLabel: project,
+		compose.ServiceLabel: service,
+	}
+}
+
+func TestDeleteVolumeClaims(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", Labels: testLabels("myproject", "db")}},
+			&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: testLabels("otherproject", "db")}},
+		),
+		namespace: "default",
+	}
+
+	claims, err := kc.DeleteVolumeClaims(ctx, "myproject")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, claims, []string{"data"})
+	list, err := kc.client.CoreV1().PersistentVolumeClaims("default").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].Name, "other")
+}
+
+func TestKillPods(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: testLabels("myproject", "web")}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "default", Labels: testLabels("myproject", "db")}},
+		),
+		namespace: "default",
+	}
+
+	pods, err := kc.KillPods(ctx, "myproject", []string{"web"}, "SIGKILL")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pods, []string{"web-1"})
+	list, err := kc.client.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].Name, "db-1")
+}
+
+func TestCompletedOneOffJobs(t *testing.T) {
+	ctx := context.Background()
+	oneOffJob := func(name string, service string, conditions ...batchv1.JobConditionType) *batchv1.Job {
+		labels := testLabels("myproject", service)
+		labels[compose.OneoffLabel] = "True"
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+		for _, c := range conditions {
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: c, Status: corev1.ConditionTrue})
+		}
+		return job
+	}
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(
+			oneOffJob("web-run-1", "web", batchv1.JobComplete),
+			oneOffJob("web-run-2", "web"),
+			oneOffJob("db-run-1", "db", batchv1.JobFailed),
+		),
+		namespace: "default",
+	}
+
+	jobs, err := kc.GetCompletedOneOffJobs(ctx, "myproject", nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, jobs, []string{"db-run-1", "web-run-1"})
+	jobs, err = kc.GetCompletedOneOffJobs(ctx, "myproject", []string{"web"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, jobs, []string{"web-run-1"})
+
+	jobs, err = kc.GetOneOffJobs(ctx, "myproject")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, jobs, []string{"db-run-1", "web-run-1", "web-run-2"})
+
+	assert.NilError(t, kc.RemoveOneOffJob(ctx, "web-run-1"))
+	jobs, err = kc.GetCompletedOneOffJobs(ctx, "myproject", nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, jobs, []string{"db-run-1"})
+}
@@ -0,0 +1,170 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// RunOneOffJob creates a Job running a one-off pod, attaches to its main container, and returns the container exit code
+func (kc KubeClient) RunOneOffJob(ctx context.Context, job *batchv1.Job, opts compose.RunOptions) (int, error) {
+	job, err := kc.client.BatchV1().Jobs(kc.namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return 0, err
+	}
+	if opts.AutoRemove && !opts.Detach {
+		defer kc.RemoveOneOffJob(context.Background(), job.Name) // nolint:errcheck
+	}
+
+	pod, err := kc.waitForJobPod(ctx, job.Name)
+	if err != nil {
+		return 0, err
+	}
+	if opts.Detach {
+		fmt.Fprintln(opts.Writer, pod.Name)
+		return 0, nil
+	}
+
+	container := job.Spec.Template.Spec.Containers[0].Name
+	if opts.Reader == nil {
+		// without stdin, following logs doesn't lose the output of a command completing before we get to attach
+		err = kc.copyLogs(ctx, pod.Name, container, true, opts.Writer)
+	} else {
+		err = kc.attachJobPod(ctx, pod.Name, container, opts)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return kc.waitForExitCode(ctx, pod.Name, container)
+}
+
+// attachJobPod attaches to a one-off pod container, falling back to its logs if the container completed meanwhile
+func (kc KubeClient) attachJobPod(ctx context.Context, podName string, container string, opts compose.RunOptions) error {
+	err := kc.stream(podName, "attach", &corev1.PodAttachOptions{
+		Container: container,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    !opts.Tty,
+		TTY:       opts.Tty,
+	}, remotecommand.StreamOptions{
+		Stdin:  opts.Reader,
+		Stdout: opts.Writer,
+		Stderr: opts.Writer,
+		Tty:    opts.Tty,
+	})
+	if err == nil {
+		return nil
+	}
+	pod, getErr := kc.client.CoreV1().Pods(kc.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if getErr != nil {
+		return err
+	}
+	if _, terminated := containerExitCode(*pod, container); !terminated {
+		return err
+	}
+	return kc.copyLogs(ctx, podName, container, false, opts.Writer)
+}
+
+func (kc KubeClient) copyLogs(ctx context.Context, podName string, container string, follow bool, w io.Writer) error {
+	r, err := kc.client.CoreV1().Pods(kc.namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close() // nolint:errcheck
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// jobPodFailureReasons are container waiting reasons which won't resolve without changing the pod spec or the image
+var jobPodFailureReasons = []string{
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+}
+
+// waitForJobPod waits for the pod created by a Job to be running, or completed
+func (kc KubeClient) waitForJobPod(ctx context.Context, jobName string) (*corev1.Pod, error) {
+	for {
+		pods, err := kc.client.CoreV1().Pods(kc.namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("job-name=%s", jobName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			switch pod.Status.Phase {
+			case corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed:
+				return &pod, nil
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if waiting := status.State.Waiting; waiting != nil && utils.StringContains(jobPodFailureReasons, waiting.Reason) {
+					return nil, fmt.Errorf("pod %s can't start: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// waitForExitCode waits for a pod container to terminate and returns its exit code
+func (kc KubeClient) waitForExitCode(ctx context.Context, podName string, container string) (int, error) {
+	for {
+		pod, err := kc.client.CoreV1().Pods(kc.namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		if exitCode, terminated := containerExitCode(*pod, container); terminated {
+			return exitCode, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func containerExitCode(pod corev1.Pod, container string) (int, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode), true
+		}
+	}
+	return 0, false
+}
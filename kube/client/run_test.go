@@ -0,0 +1,153 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestWaitForJobPodExitCode(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myproject-app-run-abcde-x1y2z",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "myproject-app-run-abcde"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:  "app",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 42}},
+					},
+				},
+			},
+		}),
+		namespace: "default",
+	}
+
+	pod, err := kc.waitForJobPod(ctx, "myproject-app-run-abcde")
+	assert.NilError(t, err)
+	assert.Equal(t, pod.Name, "myproject-app-run-abcde-x1y2z")
+
+	exitCode, err := kc.waitForExitCode(ctx, pod.Name, "app")
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 42)
+}
+
+func TestRunOneOffJobCompletedBeforeAttach(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myproject-app-run-abcde-x1y2z",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "myproject-app-run-abcde"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:  "app",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+					},
+				},
+			},
+		}),
+		namespace: "default",
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "myproject-app-run-abcde"},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Command: []string{"echo", "hi"}}},
+		}}},
+	}
+
+	out := &bytes.Buffer{}
+	exitCode, err := kc.RunOneOffJob(ctx, job, compose.RunOptions{Writer: nopCloser{out}})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 0)
+	// fake clientset serves "fake logs" as pod logs
+	assert.Equal(t, out.String(), "fake logs")
+}
+
+func TestWaitForJobPodImagePullFailure(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myproject-app-run-abcde-x1y2z",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "myproject-app-run-abcde"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "app",
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: `Back-off pulling image "app:missing"`,
+						}},
+					},
+				},
+			},
+		}),
+		namespace: "default",
+	}
+
+	_, err := kc.waitForJobPod(ctx, "myproject-app-run-abcde")
+	assert.ErrorContains(t, err, "ImagePullBackOff")
+}
+
+func TestContainerExitCode(t *testing.T) {
+	pod := corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "app",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+	_, terminated := containerExitCode(pod, "app")
+	assert.Assert(t, !terminated)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,305 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+const (
+	// ReplicasAnnotation records the replicas count of a stopped Deployment
+	ReplicasAnnotation = "com.docker.compose.replicas"
+	// RestartedAtAnnotation is set on pod templates to trigger a rollout
+	RestartedAtAnnotation = "com.docker.compose.restartedAt"
+	// StoppedNodeSelector is added to a stopped DaemonSet pod template so it doesn't match any node
+	StoppedNodeSelector = "com.docker.compose.stopped"
+)
+
+// StopServices scales project services down to zero, remembering the replicas count to restore on start
+func (kc KubeClient) StopServices(ctx context.Context, projectName string, services []string) error {
+	return kc.updateWorkloads(ctx, projectName, services, func(d *apps.Deployment) bool {
+		return scaleDown(&d.ObjectMeta, &d.Spec.Replicas)
+	}, func(d *apps.DaemonSet) bool {
+		if _, ok := d.Spec.Template.Spec.NodeSelector[StoppedNodeSelector]; ok {
+			return false
+		}
+		if d.Spec.Template.Spec.NodeSelector == nil {
+			d.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		d.Spec.Template.Spec.NodeSelector[StoppedNodeSelector] = "true"
+		return true
+	}, func(s *apps.StatefulSet) bool {
+		return scaleDown(&s.ObjectMeta, &s.Spec.Replicas)
+	})
+}
+
+// StartServices restores the replicas count of project services stopped by StopServices
+func (kc KubeClient) StartServices(ctx context.Context, projectName string, services []string) error {
+	return kc.updateWorkloads(ctx, projectName, services, func(d *apps.Deployment) bool {
+		return scaleUp(&d.ObjectMeta, &d.Spec.Replicas)
+	}, func(d *apps.DaemonSet) bool {
+		if _, ok := d.Spec.Template.Spec.NodeSelector[StoppedNodeSelector]; !ok {
+			return false
+		}
+		delete(d.Spec.Template.Spec.NodeSelector, StoppedNodeSelector)
+		return true
+	}, func(s *apps.StatefulSet) bool {
+		return scaleUp(&s.ObjectMeta, &s.Spec.Replicas)
+	})
+}
+
+// RestartServices triggers a rollout of project services, so pods get replaced
+func (kc KubeClient) RestartServices(ctx context.Context, projectName string, services []string) error {
+	now := time.Now().Format(time.RFC3339)
+	return kc.updateWorkloads(ctx, projectName, services, func(d *apps.Deployment) bool {
+		return restartPods(&d.Spec.Template, now)
+	}, func(d *apps.DaemonSet) bool {
+		return restartPods(&d.Spec.Template, now)
+	}, func(s *apps.StatefulSet) bool {
+		return restartPods(&s.Spec.Template, now)
+	})
+}
+
+// WaitForRollout waits for project services Deployments, DaemonSets and StatefulSets to run the expected number of
+// up-to-date and ready pods. Checking pods alone isn't enough, as old pods are still running when a rollout starts.
+func (kc KubeClient) WaitForRollout(ctx context.Context, projectName string, services []string, log LogFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		done, err := kc.checkRollouts(ctx, projectName, services, log)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout: services did not complete rollout")
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (kc KubeClient) checkRollouts(ctx context.Context, projectName string, services []string, log LogFunc) (bool, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	}
+	allDone := true
+	check := func(labels map[string]string, done bool, message string) {
+		service := labels[compose.ServiceLabel]
+		if len(services) > 0 && !utils.StringContains(services, service) {
+			return
+		}
+		allDone = allDone && done
+		if log != nil {
+			log(service, done, message)
+		}
+	}
+
+	deployments, err := kc.client.AppsV1().Deployments(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range deployments.Items {
+		done, message := deploymentRolledOut(d)
+		check(d.Labels, done, message)
+	}
+	daemonSets, err := kc.client.AppsV1().DaemonSets(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range daemonSets.Items {
+		done, message := daemonSetRolledOut(d)
+		check(d.Labels, done, message)
+	}
+	statefulSets, err := kc.client.AppsV1().StatefulSets(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range statefulSets.Items {
+		done, message := statefulSetRolledOut(s)
+		check(s.Labels, done, message)
+	}
+	return allDone, nil
+}
+
+func deploymentRolledOut(d apps.Deployment) (bool, string) {
+	desired := desiredReplicas(d.Spec.Replicas)
+	switch {
+	case d.Status.ObservedGeneration < d.Generation:
+		return false, "Waiting for rollout to start"
+	case d.Status.UpdatedReplicas < desired:
+		return false, fmt.Sprintf("%d/%d replicas updated", d.Status.UpdatedReplicas, desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.ReadyReplicas < desired:
+		return false, fmt.Sprintf("%d/%d replicas ready", d.Status.ReadyReplicas, desired)
+	}
+	return true, fmt.Sprintf("%d/%d replicas ready", desired, desired)
+}
+
+func statefulSetRolledOut(s apps.StatefulSet) (bool, string) {
+	desired := desiredReplicas(s.Spec.Replicas)
+	switch {
+	case s.Status.ObservedGeneration < s.Generation:
+		return false, "Waiting for rollout to start"
+	case s.Status.UpdatedReplicas < desired:
+		return false, fmt.Sprintf("%d/%d replicas updated", s.Status.UpdatedReplicas, desired)
+	case s.Status.Replicas > desired:
+		return false, fmt.Sprintf("%d extra replicas pending termination", s.Status.Replicas-desired)
+	case s.Status.ReadyReplicas < desired:
+		return false, fmt.Sprintf("%d/%d replicas ready", s.Status.ReadyReplicas, desired)
+	}
+	return true, fmt.Sprintf("%d/%d replicas ready", desired, desired)
+}
+
+func daemonSetRolledOut(d apps.DaemonSet) (bool, string) {
+	desired := d.Status.DesiredNumberScheduled
+	switch {
+	case d.Status.ObservedGeneration < d.Generation:
+		return false, "Waiting for rollout to start"
+	case d.Status.UpdatedNumberScheduled < desired:
+		return false, fmt.Sprintf("%d/%d pods updated", d.Status.UpdatedNumberScheduled, desired)
+	case d.Status.NumberReady < desired:
+		return false, fmt.Sprintf("%d/%d pods ready", d.Status.NumberReady, desired)
+	}
+	return true, fmt.Sprintf("%d/%d pods ready", desired, desired)
+}
+
+// desiredReplicas returns the replicas count of a workload spec, which defaults to 1
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func scaleDown(meta *metav1.ObjectMeta, replicas **int32) bool {
+	if *replicas == nil || **replicas == 0 {
+		return false
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ReplicasAnnotation] = strconv.Itoa(int(**replicas))
+	zero := int32(0)
+	*replicas = &zero
+	return true
+}
+
+func scaleUp(meta *metav1.ObjectMeta, replicas **int32) bool {
+	value, ok := meta.Annotations[ReplicasAnnotation]
+	if !ok {
+		return false
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		count = 1
+	}
+	r := int32(count)
+	*replicas = &r
+	delete(meta.Annotations, ReplicasAnnotation)
+	return true
+}
+
+func restartPods(template *corev1.PodTemplateSpec, restartedAt string) bool {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[RestartedAtAnnotation] = restartedAt
+	return true
+}
+
+// updateWorkloads applies changes to project services Deployments, DaemonSets and StatefulSets. Objects are only
+// updated when the change func returns true.
+func (kc KubeClient) updateWorkloads(ctx context.Context, projectName string, services []string,
+	changeDeployment func(d *apps.Deployment) bool, changeDaemonSet func(d *apps.DaemonSet) bool,
+	changeStatefulSet func(s *apps.StatefulSet) bool) error {
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	}
+	deployments, err := kc.client.AppsV1().Deployments(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for _, d := range deployments.Items {
+		d := d
+		if len(services) > 0 && !utils.StringContains(services, d.Labels[compose.ServiceLabel]) {
+			continue
+		}
+		if !changeDeployment(&d) {
+			continue
+		}
+		if _, err := kc.client.AppsV1().Deployments(kc.namespace).Update(ctx, &d, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	daemonSets, err := kc.client.AppsV1().DaemonSets(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for _, d := range daemonSets.Items {
+		d := d
+		if len(services) > 0 && !utils.StringContains(services, d.Labels[compose.ServiceLabel]) {
+			continue
+		}
+		if !changeDaemonSet(&d) {
+			continue
+		}
+		if _, err := kc.client.AppsV1().DaemonSets(kc.namespace).Update(ctx, &d, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	statefulSets, err := kc.client.AppsV1().StatefulSets(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for _, s := range statefulSets.Items {
+		s := s
+		if len(services) > 0 && !utils.StringContains(services, s.Labels[compose.ServiceLabel]) {
+			continue
+		}
+		if !changeStatefulSet(&s) {
+			continue
+		}
+		if _, err := kc.client.AppsV1().StatefulSets(kc.namespace).Update(ctx, &s, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
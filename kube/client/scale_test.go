@@ -0,0 +1,200 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func testDeployment(service string, replicas int32) *apps.Deployment {
+	return &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service,
+			Namespace: "default",
+			Labels: map[string]string{
+				compose.ProjectLabel: "myproject",
+				compose.ServiceLabel: service,
+			},
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}
+}
+
+func TestStopStartServices(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(testDeployment("web", 3), testDeployment("db", 1)),
+		namespace: "default",
+	}
+
+	assert.NilError(t, kc.StopServices(ctx, "myproject", []string{"web"}))
+	web, err := kc.client.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *web.Spec.Replicas, int32(0))
+	assert.Equal(t, web.Annotations[ReplicasAnnotation], "3")
+	db, err := kc.client.AppsV1().Deployments("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *db.Spec.Replicas, int32(1))
+
+	// stopping again must not lose the recorded replicas count
+	assert.NilError(t, kc.StopServices(ctx, "myproject", nil))
+	web, err = kc.client.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, web.Annotations[ReplicasAnnotation], "3")
+
+	assert.NilError(t, kc.StartServices(ctx, "myproject", nil))
+	web, err = kc.client.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *web.Spec.Replicas, int32(3))
+	_, ok := web.Annotations[ReplicasAnnotation]
+	assert.Assert(t, !ok)
+	db, err = kc.client.AppsV1().Deployments("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *db.Spec.Replicas, int32(1))
+}
+
+func TestStopStartDaemonSet(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(&apps.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent",
+				Namespace: "default",
+				Labels: map[string]string{
+					compose.ProjectLabel: "myproject",
+					compose.ServiceLabel: "agent",
+				},
+			},
+		}),
+		namespace: "default",
+	}
+
+	assert.NilError(t, kc.StopServices(ctx, "myproject", nil))
+	agent, err := kc.client.AppsV1().DaemonSets("default").Get(ctx, "agent", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, agent.Spec.Template.Spec.NodeSelector[StoppedNodeSelector], "true")
+
+	assert.NilError(t, kc.StartServices(ctx, "myproject", nil))
+	agent, err = kc.client.AppsV1().DaemonSets("default").Get(ctx, "agent", metav1.GetOptions{})
+	assert.NilError(t, err)
+	_, ok := agent.Spec.Template.Spec.NodeSelector[StoppedNodeSelector]
+	assert.Assert(t, !ok)
+}
+
+func TestRestartServices(t *testing.T) {
+	ctx := context.Background()
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(testDeployment("web", 1)),
+		namespace: "default",
+	}
+
+	assert.NilError(t, kc.RestartServices(ctx, "myproject", []string{"web"}))
+	web, err := kc.client.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, web.Spec.Template.Annotations[RestartedAtAnnotation] != "")
+}
+
+func TestStopStartStatefulSet(t *testing.T) {
+	ctx := context.Background()
+	replicas := int32(2)
+	kc := KubeClient{
+		client: fake.NewSimpleClientset(&apps.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: testLabels("myproject", "db")},
+			Spec:       apps.StatefulSetSpec{Replicas: &replicas},
+		}),
+		namespace: "default",
+	}
+
+	assert.NilError(t, kc.StopServices(ctx, "myproject", nil))
+	db, err := kc.client.AppsV1().StatefulSets("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *db.Spec.Replicas, int32(0))
+	assert.Equal(t, db.Annotations[ReplicasAnnotation], "2")
+
+	assert.NilError(t, kc.StartServices(ctx, "myproject", nil))
+	db, err = kc.client.AppsV1().StatefulSets("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *db.Spec.Replicas, int32(2))
+}
+
+func TestCheckRollouts(t *testing.T) {
+	ctx := context.Background()
+	web := testDeployment("web", 2)
+	web.Generation = 2
+	web.Status = apps.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2}
+	db := testDeployment("db", 1)
+	kc := KubeClient{
+		client:    fake.NewSimpleClientset(web, db),
+		namespace: "default",
+	}
+
+	// new generation has not been observed yet, so pods still are the old ones
+	done, err := kc.checkRollouts(ctx, "myproject", []string{"web"}, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !done)
+
+	web.Status = apps.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, ReadyReplicas: 2}
+	_, err = kc.client.AppsV1().Deployments("default").UpdateStatus(ctx, web, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	done, err = kc.checkRollouts(ctx, "myproject", []string{"web"}, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !done, "an old replica is still running")
+
+	web.Status.Replicas = 2
+	_, err = kc.client.AppsV1().Deployments("default").UpdateStatus(ctx, web, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	var messages []string
+	done, err = kc.checkRollouts(ctx, "myproject", []string{"web"}, func(service string, stateReached bool, message string) {
+		messages = append(messages, service+": "+message)
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, done)
+	assert.DeepEqual(t, messages, []string{"web: 2/2 replicas ready"})
+
+	// db has no ready replica
+	done, err = kc.checkRollouts(ctx, "myproject", nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !done)
+}
+
+func TestStatefulSetRolledOut(t *testing.T) {
+	replicas := int32(2)
+	db := apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec:       apps.StatefulSetSpec{Replicas: &replicas},
+		Status:     apps.StatefulSetStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2},
+	}
+	done, message := statefulSetRolledOut(db)
+	assert.Assert(t, !done)
+	assert.Equal(t, message, "1/2 replicas updated")
+
+	db.Status.UpdatedReplicas = 2
+	done, _ = statefulSetRolledOut(db)
+	assert.Assert(t, done)
+}
@@ -0,0 +1,102 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// Top lists the processes running in project pods, by running `ps` in pod main container
+func (kc KubeClient) Top(ctx context.Context, projectName string, services []string) ([]compose.ContainerProcSummary, error) {
+	pods, err := kc.getProjectPods(ctx, projectName, services, true)
+	if err != nil {
+		return nil, err
+	}
+	summary := make([]compose.ContainerProcSummary, len(pods))
+	eg := errgroup.Group{}
+	for i, pod := range pods {
+		i, pod := i, pod
+		eg.Go(func() error {
+			stdout := bytes.Buffer{}
+			if err := kc.ExecInPod(pod, []string{"ps"}, nil, &stdout); err != nil {
+				return err
+			}
+			titles, processes := parsePs(stdout.String())
+			summary[i] = compose.ContainerProcSummary{
+				ID:        string(pod.UID),
+				Name:      pod.Name,
+				Titles:    titles,
+				Processes: processes,
+			}
+			return nil
+		})
+	}
+	return summary, eg.Wait()
+}
+
+// parsePs parses `ps` output, the last column (command) being allowed to hold spaces
+func parsePs(output string) ([]string, [][]string) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	titles := strings.Fields(lines[0])
+	var processes [][]string
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > len(titles) {
+			command := strings.Join(fields[len(titles)-1:], " ")
+			fields = append(fields[:len(titles)-1], command)
+		}
+		processes = append(processes, fields)
+	}
+	return titles, processes
+}
+
+// getProjectPods lists project pods, optionally filtered by services
+func (kc KubeClient) getProjectPods(ctx context.Context, projectName string, services []string, running bool) ([]corev1.Pod, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", compose.ProjectLabel, projectName),
+	}
+	if running {
+		listOptions.FieldSelector = "status.phase=Running"
+	}
+	pods, err := kc.client.CoreV1().Pods(kc.namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		if len(services) > 0 && !utils.StringContains(services, pod.Labels[compose.ServiceLabel]) {
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParsePs(t *testing.T) {
+	titles, processes := parsePs(`PID   USER     TIME  COMMAND
+    1 root      0:00 nginx: master process nginx -g daemon off;
+   32 nginx     0:00 nginx: worker process
+`)
+	assert.DeepEqual(t, titles, []string{"PID", "USER", "TIME", "COMMAND"})
+	assert.DeepEqual(t, processes, [][]string{
+		{"1", "root", "0:00", "nginx: master process nginx -g daemon off;"},
+		{"32", "nginx", "0:00", "nginx: worker process"},
+	})
+}
@@ -20,6 +20,7 @@ package client
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/compose-cli/api/compose"
@@ -62,6 +63,10 @@ func checkPodsState(services []string, pods []corev1.Pod, status string) (bool,
 		if len(services) > 0 && !utils.StringContains(services, service) {
 			continue
 		}
+		if pod.Labels[compose.OneoffLabel] == "True" {
+			// pods run by `compose run` don't reflect service state
+			continue
+		}
 		containersRunning := true
 		for _, container := range pod.Status.ContainerStatuses {
 			if container.State.Running == nil {
@@ -84,6 +89,10 @@ func checkPodsState(services []string, pods []corev1.Pod, status string) (bool,
 	if status == compose.REMOVING && len(servicePods) > 0 {
 		stateReached = false
 	}
+	if status == compose.RUNNING && len(servicePods) == 0 {
+		// pods are not created yet
+		stateReached = false
+	}
 	return stateReached, servicePods, nil
 }
 
@@ -106,4 +115,21 @@ type Ports []compose.PortPublisher
 type PortMappingOptions struct {
 	ProjectName string
 	Services    map[string]Ports
+	// Address local ports are bound to, defaults to localhost
+	Address string
+	// Log receives forwarding status messages
+	Log func(message string)
+}
+
+// splitLogTimestamp splits the RFC3339 timestamp kubernetes adds as log line prefix from the actual message
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, line, false
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return timestamp, parts[1], true
 }
@@ -21,10 +21,15 @@ package kube
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/types"
+	cliconfig "github.com/docker/cli/cli/config"
+	moby "github.com/docker/docker/client"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/rand"
 
 	"github.com/docker/compose-cli/api/compose"
 	apicontext "github.com/docker/compose-cli/api/context"
@@ -34,12 +39,17 @@ import (
 	"github.com/docker/compose-cli/kube/client"
 	"github.com/docker/compose-cli/kube/helm"
 	"github.com/docker/compose-cli/kube/resources"
+	local_compose "github.com/docker/compose-cli/local/compose"
 	"github.com/docker/compose-cli/utils"
+	"github.com/docker/compose-cli/utils/prompt"
 )
 
 type composeService struct {
-	sdk    *helm.Actions
-	client *client.KubeClient
+	sdk       *helm.Actions
+	client    *client.KubeClient
+	local     compose.Service
+	apiClient moby.APIClient
+	registry  string
 }
 
 // NewComposeService create a kubernetes implementation of the compose.Service API
@@ -63,10 +73,17 @@ func NewComposeService() (compose.Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	mobyClient, err := moby.NewClientWithOpts(moby.FromEnv, moby.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
 
 	return &composeService{
-		sdk:    actions,
-		client: apiClient,
+		sdk:       actions,
+		client:    apiClient,
+		local:     local_compose.NewComposeService(mobyClient, cliconfig.LoadDefaultConfigFile(os.Stderr)),
+		apiClient: mobyClient,
+		registry:  kubeContext.Registry,
 	}, nil
 }
 
@@ -79,11 +96,35 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 
 func (s *composeService) up(ctx context.Context, project *types.Project) error {
 	w := progress.ContextWriter(ctx)
+	images, err := s.prepareImages(ctx, project)
+	if err != nil {
+		return err
+	}
+	if err := s.installOrUpdate(ctx, project, images); err != nil {
+		return err
+	}
+
+	return s.client.WaitForPodState(ctx, client.WaitForStatusOptions{
+		ProjectName: project.Name,
+		Services:    project.ServiceNames(),
+		Status:      compose.RUNNING,
+		Log: func(pod string, stateReached bool, message string) {
+			state := progress.Done
+			if !stateReached {
+				state = progress.Working
+			}
+			w.Event(progress.NewEvent(pod, state, message))
+		},
+	})
+}
+
+func (s *composeService) installOrUpdate(ctx context.Context, project *types.Project, images map[string]string) error {
+	w := progress.ContextWriter(ctx)
 
 	eventName := "Convert Compose file to Helm charts"
 	w.Event(progress.CreatingEvent(eventName))
 
-	chart, err := helm.GetChartInMemory(project)
+	chart, err := helm.GetChartInMemory(project, images)
 	if err != nil {
 		return err
 	}
@@ -115,26 +156,11 @@ func (s *composeService) up(ctx context.Context, project *types.Project) error {
 	}
 
 	w.Event(progress.NewEvent(eventName, progress.Done, ""))
-
-	return s.client.WaitForPodState(ctx, client.WaitForStatusOptions{
-		ProjectName: project.Name,
-		Services:    project.ServiceNames(),
-		Status:      compose.RUNNING,
-		Log: func(pod string, stateReached bool, message string) {
-			state := progress.Done
-			if !stateReached {
-				state = progress.Working
-			}
-			w.Event(progress.NewEvent(pod, state, message))
-		},
-	})
+	return nil
 }
 
 // Down executes the equivalent to a `compose down`
 func (s *composeService) Down(ctx context.Context, projectName string, options compose.DownOptions) error {
-	if options.Volumes {
-		return errors.Wrap(errdefs.ErrNotImplemented, "--volumes option is not supported on Kubernetes")
-	}
 	if options.Images != "" {
 		return errors.Wrap(errdefs.ErrNotImplemented, "--rmi option is not supported on Kubernetes")
 	}
@@ -163,6 +189,26 @@ func (s *composeService) down(ctx context.Context, projectName string, options c
 	if err != nil {
 		return err
 	}
+	// one-off Jobs are created by `compose run`, not by the Helm release
+	jobs, err := s.client.GetOneOffJobs(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if err := s.client.RemoveOneOffJob(ctx, job); err != nil {
+			return err
+		}
+		w.Event(progress.RemovedEvent(fmt.Sprintf("Job %s", job)))
+	}
+	if options.Volumes {
+		claims, err := s.client.DeleteVolumeClaims(ctx, projectName)
+		for _, claim := range claims {
+			w.Event(progress.RemovedEvent(fmt.Sprintf("Volume %s", claim)))
+		}
+		if err != nil {
+			return err
+		}
+	}
 
 	events := []string{}
 	err = s.client.WaitForPodState(ctx, client.WaitForStatusOptions{
@@ -196,44 +242,103 @@ func (s *composeService) List(ctx context.Context, opts compose.ListOptions) ([]
 	return s.sdk.ListReleases()
 }
 
-// Build executes the equivalent to a `compose build`
-func (s *composeService) Build(ctx context.Context, project *types.Project, options compose.BuildOptions) error {
-	return errdefs.ErrNotImplemented
-}
-
-// Push executes the equivalent ot a `compose push`
-func (s *composeService) Push(ctx context.Context, project *types.Project, options compose.PushOptions) error {
-	return errdefs.ErrNotImplemented
-}
-
-// Pull executes the equivalent of a `compose pull`
-func (s *composeService) Pull(ctx context.Context, project *types.Project, options compose.PullOptions) error {
-	return errdefs.ErrNotImplemented
-}
-
 // Create executes the equivalent to a `compose create`
 func (s *composeService) Create(ctx context.Context, project *types.Project, opts compose.CreateOptions) error {
-	return errdefs.ErrNotImplemented
+	if len(project.Services) == 0 {
+		return nil
+	}
+	stack, err := s.sdk.Get(project.Name)
+	if err == nil && stack != nil && len(project.DisabledServices) > 0 {
+		// upgrading the release with a partial project, i.e. run dependencies, would remove disabled services
+		return nil
+	}
+	return progress.Run(ctx, func(ctx context.Context) error {
+		images, err := s.prepareImages(ctx, project)
+		if err != nil {
+			return err
+		}
+		return s.installOrUpdate(ctx, project, images)
+	})
 }
 
 // Start executes the equivalent to a `compose start`
 func (s *composeService) Start(ctx context.Context, project *types.Project, options compose.StartOptions) error {
-	return errdefs.ErrNotImplemented
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return s.start(ctx, project.Name, project.ServiceNames())
+	})
+}
+
+func (s *composeService) start(ctx context.Context, projectName string, services []string) error {
+	w := progress.ContextWriter(ctx)
+	eventName := fmt.Sprintf("Start %s", projectName)
+	w.Event(progress.StartingEvent(eventName))
+	if err := s.client.StartServices(ctx, projectName, services); err != nil {
+		return err
+	}
+	if err := s.client.WaitForRollout(ctx, projectName, services, podStateLogger(w)); err != nil {
+		return err
+	}
+	w.Event(progress.StartedEvent(eventName))
+	return nil
 }
 
 // Restart executes the equivalent to a `compose restart`
 func (s *composeService) Restart(ctx context.Context, project *types.Project, options compose.RestartOptions) error {
-	return errdefs.ErrNotImplemented
+	return progress.Run(ctx, func(ctx context.Context) error {
+		w := progress.ContextWriter(ctx)
+		eventName := fmt.Sprintf("Restart %s", project.Name)
+		w.Event(progress.RestartingEvent(eventName))
+		services := options.Services
+		if len(services) == 0 {
+			services = project.ServiceNames()
+		}
+		if err := s.client.RestartServices(ctx, project.Name, services); err != nil {
+			return err
+		}
+		if err := s.client.WaitForRollout(ctx, project.Name, services, podStateLogger(w)); err != nil {
+			return err
+		}
+		w.Event(progress.NewEvent(eventName, progress.Done, "Restarted"))
+		return nil
+	})
 }
 
 // Stop executes the equivalent to a `compose stop`
 func (s *composeService) Stop(ctx context.Context, project *types.Project, options compose.StopOptions) error {
-	return errdefs.ErrNotImplemented
+	return progress.Run(ctx, func(ctx context.Context) error {
+		w := progress.ContextWriter(ctx)
+		eventName := fmt.Sprintf("Stop %s", project.Name)
+		w.Event(progress.StoppingEvent(eventName))
+		services := options.Services
+		if len(services) == 0 {
+			services = project.ServiceNames()
+		}
+		if err := s.client.StopServices(ctx, project.Name, services); err != nil {
+			return err
+		}
+		err := s.client.WaitForPodState(ctx, client.WaitForStatusOptions{
+			ProjectName: project.Name,
+			Services:    services,
+			Status:      compose.REMOVING,
+			Timeout:     options.Timeout,
+			Log:         podStateLogger(w),
+		})
+		if err != nil {
+			return err
+		}
+		w.Event(progress.StoppedEvent(eventName))
+		return nil
+	})
 }
 
-// Copy copies a file/folder between a service container and the local filesystem
-func (s *composeService) Copy(ctx context.Context, project *types.Project, options compose.CopyOptions) error {
-	return errdefs.ErrNotImplemented
+func podStateLogger(w progress.Writer) client.LogFunc {
+	return func(pod string, stateReached bool, message string) {
+		state := progress.Done
+		if !stateReached {
+			state = progress.Working
+		}
+		w.Event(progress.NewEvent(pod, state, message))
+	}
 }
 
 // Logs executes the equivalent to a `compose logs`
@@ -241,18 +346,28 @@ func (s *composeService) Logs(ctx context.Context, projectName string, consumer
 	if len(options.Services) > 0 {
 		consumer = utils.FilteredLogConsumer(consumer, options.Services)
 	}
-	return s.client.GetLogs(ctx, projectName, consumer, options.Follow)
+	consumer, err := utils.GrepLogConsumer(consumer, options.Grep, options.Timestamps)
+	if err != nil {
+		return err
+	}
+	return s.client.GetLogs(ctx, projectName, consumer, options)
 }
 
 // Ps executes the equivalent to a `compose ps`
 func (s *composeService) Ps(ctx context.Context, projectName string, options compose.PsOptions) ([]compose.ContainerSummary, error) {
+	if len(options.Status) > 0 || len(options.Health) > 0 || len(options.Labels) > 0 {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--filter option is not supported on Kubernetes")
+	}
 	return s.client.GetContainers(ctx, projectName, options.All)
 }
 
 // Convert translate compose model into backend's native format
 func (s *composeService) Convert(ctx context.Context, project *types.Project, options compose.ConvertOptions) ([]byte, error) {
+	if options.FromRunning {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--from-running option is not supported on Kubernetes")
+	}
 
-	chart, err := helm.GetChartInMemory(project)
+	chart, err := helm.GetChartInMemory(project, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -272,44 +387,242 @@ func (s *composeService) Convert(ctx context.Context, project *types.Project, op
 	return buff, nil
 }
 
+// Kill sends a signal to project pods, SIGKILL deletes them without grace period
 func (s *composeService) Kill(ctx context.Context, project *types.Project, options compose.KillOptions) error {
-	return errdefs.ErrNotImplemented
+	return progress.Run(ctx, func(ctx context.Context) error {
+		w := progress.ContextWriter(ctx)
+		pods, err := s.client.KillPods(ctx, project.Name, project.ServiceNames(), options.Signal)
+		for _, pod := range pods {
+			w.Event(progress.NewEvent(pod, progress.Done, "Killed"))
+		}
+		return err
+	})
 }
 
 // RunOneOffContainer creates a service oneoff container and starts its dependencies
 func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) (int, error) {
-	return 0, errdefs.ErrNotImplemented
+	service, err := project.GetService(opts.Service)
+	if err != nil {
+		return 0, err
+	}
+	applyRunOptions(project, &service, opts)
+
+	runProject := *project
+	runProject.Services = types.Services{service}
+	images, err := s.prepareImages(ctx, &runProject)
+	if err != nil {
+		return 0, err
+	}
+	if image, ok := images[service.Name]; ok {
+		service.Image = image
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%s-run-%s", project.Name, service.Name, rand.String(5))
+	}
+	job, err := resources.MapToOneOffJob(project, service, strings.ReplaceAll(strings.ToLower(name), "_", "-"))
+	if err != nil {
+		return 0, err
+	}
+	return s.client.RunOneOffJob(ctx, job, opts)
+}
+
+func applyRunOptions(project *types.Project, service *types.ServiceConfig, opts compose.RunOptions) {
+	service.Tty = opts.Tty
+	service.StdinOpen = opts.Reader != nil
+	service.Privileged = service.Privileged || opts.Privileged
+	if len(opts.Command) > 0 {
+		service.Command = opts.Command
+	}
+	if len(opts.User) > 0 {
+		service.User = opts.User
+	}
+	if len(opts.WorkingDir) > 0 {
+		service.WorkingDir = opts.WorkingDir
+	}
+	if len(opts.Entrypoint) > 0 {
+		service.Entrypoint = opts.Entrypoint
+	}
+	if len(opts.Environment) > 0 {
+		env := types.NewMappingWithEquals(opts.Environment)
+		projectEnv := env.Resolve(func(s string) (string, bool) {
+			v, ok := project.Environment[s]
+			return v, ok
+		}).RemoveEmpty()
+		service.Environment = service.Environment.OverrideBy(projectEnv)
+	}
+	labels := types.Labels{}
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+	service.Labels = labels
 }
 
+// Remove deletes completed one-off pods, along with their Job
 func (s *composeService) Remove(ctx context.Context, project *types.Project, options compose.RemoveOptions) error {
-	return errdefs.ErrNotImplemented
+	services := options.Services
+	if len(services) == 0 {
+		services = project.ServiceNames()
+	}
+	jobs, err := s.client.GetCompletedOneOffJobs(ctx, project.Name, services)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		fmt.Println("No stopped containers")
+		return nil
+	}
+	msg := fmt.Sprintf("Going to remove %s", strings.Join(jobs, ", "))
+	if options.Force {
+		fmt.Println(msg)
+	} else {
+		confirm, err := prompt.User{}.Confirm(msg, false)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			return nil
+		}
+	}
+	return progress.Run(ctx, func(ctx context.Context) error {
+		w := progress.ContextWriter(ctx)
+		for _, job := range jobs {
+			w.Event(progress.RemovingEvent(job))
+			if err := s.client.RemoveOneOffJob(ctx, job); err != nil {
+				return err
+			}
+			w.Event(progress.RemovedEvent(job))
+		}
+		return nil
+	})
 }
 
 // Exec executes a command in a running service container
 func (s *composeService) Exec(ctx context.Context, project *types.Project, opts compose.RunOptions) (int, error) {
+	if opts.All {
+		return 0, errors.Wrap(errdefs.ErrNotImplemented, "--all option is not supported on Kubernetes")
+	}
 	return 0, s.client.Exec(ctx, project.Name, opts)
 }
 
+// Pause is rejected as Kubernetes can't freeze pods, scaling down is done by `compose stop`
 func (s *composeService) Pause(ctx context.Context, project string, options compose.PauseOptions) error {
-	return errdefs.ErrNotImplemented
+	return pauseNotSupported("pause", options.Services)
 }
 
+// UnPause is rejected as Kubernetes can't freeze pods, scaling up is done by `compose start`
 func (s *composeService) UnPause(ctx context.Context, project string, options compose.PauseOptions) error {
-	return errdefs.ErrNotImplemented
+	return pauseNotSupported("unpause", options.Services)
+}
+
+func pauseNotSupported(action string, services []string) error {
+	alternative := "stop"
+	if action == "unpause" {
+		alternative = "start"
+	}
+	if len(services) == 0 {
+		return errors.Wrapf(errdefs.ErrNotImplemented, "cannot %s services on Kubernetes, use `compose %s` instead", action, alternative)
+	}
+	return errors.Wrapf(errdefs.ErrNotImplemented, "cannot %s service(s) %s on Kubernetes, use `compose %s` instead",
+		action, strings.Join(services, ", "), alternative)
 }
 
+// Top lists processes running in project pods
 func (s *composeService) Top(ctx context.Context, projectName string, services []string) ([]compose.ContainerProcSummary, error) {
-	return nil, errdefs.ErrNotImplemented
+	return s.client.Top(ctx, projectName, services)
 }
 
+// Events streams Kubernetes events for project pods
 func (s *composeService) Events(ctx context.Context, project string, options compose.EventsOptions) error {
-	return errdefs.ErrNotImplemented
+	now := time.Now()
+	since, err := utils.ParseLogTime(options.Since, now)
+	if err != nil {
+		return err
+	}
+	until, err := utils.ParseLogTime(options.Until, now)
+	if err != nil {
+		return err
+	}
+	return s.client.WatchEvents(ctx, client.WatchEventsOptions{
+		ProjectName: project,
+		Since:       since,
+		Until:       until,
+		Consumer: func(event compose.Event) error {
+			if len(options.Services) > 0 && !utils.StringContains(options.Services, event.Service) {
+				return nil
+			}
+			if len(options.Types) > 0 && !utils.StringContains(options.Types, event.Status) {
+				return nil
+			}
+			return options.Consumer(event)
+		},
+	})
 }
 
+// Port returns the external address a service port is published on by its Kubernetes Service
 func (s *composeService) Port(ctx context.Context, project string, service string, port int, options compose.PortOptions) (string, int, error) {
-	return "", 0, errdefs.ErrNotImplemented
+	return s.client.GetPublishedPort(ctx, project, service, port, options.Protocol)
 }
 
+// PortForward forwards local ports to the published ports of project services, until ctx is done
+func (s *composeService) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	services := map[string]client.Ports{}
+	for _, service := range project.Services {
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, service.Name) {
+			continue
+		}
+		var ports client.Ports
+		for _, p := range service.Ports {
+			if p.Protocol != "" && p.Protocol != "tcp" {
+				continue
+			}
+			published := p.Published
+			if published == 0 {
+				published = p.Target
+			}
+			ports = append(ports, compose.PortPublisher{
+				URL:           options.Address,
+				TargetPort:    int(p.Target),
+				PublishedPort: int(published),
+				Protocol:      "tcp",
+			})
+		}
+		if len(ports) > 0 {
+			services[service.Name] = ports
+		}
+	}
+	if len(services) == 0 {
+		return fmt.Errorf("no published ports to forward")
+	}
+	return s.client.ForwardPorts(ctx, client.PortMappingOptions{
+		ProjectName: project.Name,
+		Services:    services,
+		Address:     options.Address,
+		Log: func(message string) {
+			if options.Writer != nil {
+				fmt.Fprintln(options.Writer, message)
+			}
+		},
+	})
+}
+
+// Images lists images used by project pods
 func (s *composeService) Images(ctx context.Context, projectName string, options compose.ImagesOptions) ([]compose.ImageSummary, error) {
-	return nil, errdefs.ErrNotImplemented
+	return s.client.Images(ctx, projectName, options.Services)
+}
+
+func (s *composeService) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *composeService) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *composeService) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	return 0, errdefs.ErrNotImplemented
 }
@@ -35,6 +35,7 @@ type ContextParams struct {
 	Description     string
 	KubeConfigPath  string
 	FromEnvironment bool
+	Registry        string
 }
 
 // CreateContextData create Docker context data
@@ -43,6 +44,7 @@ func (cp ContextParams) CreateContextData() (interface{}, string, error) {
 		// we use the current kubectl context from a $KUBECONFIG path
 		return store.KubeContext{
 			FromEnvironment: cp.FromEnvironment,
+			Registry:        cp.Registry,
 		}, cp.getDescription(), nil
 	}
 	user := prompt.User{}
@@ -69,6 +71,7 @@ func (cp ContextParams) CreateContextData() (interface{}, string, error) {
 				ContextName:     cp.KubeContextName,
 				KubeconfigPath:  cp.KubeConfigPath,
 				FromEnvironment: cp.FromEnvironment,
+				Registry:        cp.Registry,
 			}, cp.getDescription(), nil
 		}
 		err := selectContext()
@@ -107,6 +110,7 @@ func (cp ContextParams) CreateContextData() (interface{}, string, error) {
 		ContextName:     cp.KubeContextName,
 		KubeconfigPath:  cp.KubeConfigPath,
 		FromEnvironment: cp.FromEnvironment,
+		Registry:        cp.Registry,
 	}, cp.getDescription(), nil
 }
 
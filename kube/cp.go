@@ -0,0 +1,355 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// Copy copies a file/folder between a service container and the local filesystem
+func (s *composeService) Copy(ctx context.Context, project *types.Project, opts compose.CopyOptions) error {
+	srcService, srcPath := utils.SplitCopyArg(opts.Source)
+	destService, dstPath := utils.SplitCopyArg(opts.Destination)
+
+	g := errgroup.Group{}
+	switch {
+	case srcService != "" && destService == "":
+		if opts.All && dstPath == "-" {
+			return errors.New("cannot use the --all flag when copying from a service to stdout")
+		}
+		pods, err := s.getCopyPods(ctx, project.Name, srcService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range pods {
+			pod := pods[i]
+			g.Go(func() error {
+				dst := dstPath
+				if opts.All {
+					// copy from each replica into its own sub-directory
+					dst = filepath.Join(dstPath, pod.Name)
+					if err := os.MkdirAll(dst, 0755); err != nil {
+						return err
+					}
+				}
+				return s.copyFromPod(pod, srcPath, dst, opts)
+			})
+		}
+	case srcService == "" && destService != "":
+		if opts.All && srcPath == "-" {
+			return errors.New("cannot use the --all flag when copying from stdin to a service")
+		}
+		pods, err := s.getCopyPods(ctx, project.Name, destService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range pods {
+			pod := pods[i]
+			g.Go(func() error {
+				return s.copyToPod(pod, srcPath, dstPath, opts)
+			})
+		}
+	case srcService != "" && destService != "":
+		sources, err := s.getCopyPods(ctx, project.Name, srcService, false, opts.Index)
+		if err != nil {
+			return err
+		}
+		pods, err := s.getCopyPods(ctx, project.Name, destService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range pods {
+			pod := pods[i]
+			g.Go(func() error {
+				return s.copyAcrossPods(sources[0], srcPath, pod, dstPath, opts)
+			})
+		}
+	default:
+		return errors.New("unknown copy direction")
+	}
+	return g.Wait()
+}
+
+// getCopyPods selects service pods, index being 1-based like container numbers
+func (s *composeService) getCopyPods(ctx context.Context, projectName string, serviceName string, all bool, index int) ([]corev1.Pod, error) {
+	pods, err := s.client.GetServicePods(ctx, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) < 1 {
+		return nil, fmt.Errorf("service %s not running", serviceName)
+	}
+	if all {
+		return pods, nil
+	}
+	if index < 1 {
+		index = 1
+	}
+	if index > len(pods) {
+		return nil, fmt.Errorf("service %s has no pod with index %d", serviceName, index)
+	}
+	return pods[index-1 : index], nil
+}
+
+func (s *composeService) copyAcrossPods(srcPod corev1.Pod, srcPath string, dstPod corev1.Pod, dstPath string, opts compose.CopyOptions) error {
+	dstInfo, err := s.getPodDestinationInfo(dstPod, dstPath)
+	if err != nil {
+		return err
+	}
+
+	if utils.HasGlobPattern(srcPath) {
+		if !dstInfo.IsDir {
+			return errors.Errorf("destination \"%s:%s\" must be a directory", dstPod.Name, dstPath)
+		}
+		content, err := s.copyGlobFromPod(srcPod, srcPath)
+		if err != nil {
+			return err
+		}
+		defer content.Close() //nolint:errcheck
+		return s.client.CopyToPod(dstPod, dstInfo.Path, content, opts.CopyUIDGID)
+	}
+
+	rebaseName := ""
+	if opts.FollowLink {
+		srcPath, rebaseName = s.followPodLink(srcPod, srcPath)
+	}
+	stat, err := s.client.StatPath(srcPod, srcPath)
+	if err != nil {
+		return err
+	}
+	if !stat.Exists {
+		return errors.Errorf("no such file or directory \"%s:%s\"", srcPod.Name, srcPath)
+	}
+
+	content := s.client.CopyFromPod(srcPod, srcPath)
+	defer content.Close() //nolint:errcheck
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      stat.IsDir,
+		RebaseName: rebaseName,
+	}
+
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(content, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close() //nolint:errcheck
+
+	return s.client.CopyToPod(dstPod, dstDir, preparedArchive, opts.CopyUIDGID)
+}
+
+func (s *composeService) copyToPod(pod corev1.Pod, srcPath string, dstPath string, opts compose.CopyOptions) error {
+	var err error
+	if srcPath != "-" {
+		// Get an absolute source path.
+		srcPath, err = utils.ResolveLocalPath(srcPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	dstInfo, err := s.getPodDestinationInfo(pod, dstPath)
+	if err != nil {
+		return err
+	}
+
+	if srcPath == "-" {
+		if !dstInfo.IsDir {
+			return errors.Errorf("destination \"%s:%s\" must be a directory", pod.Name, dstPath)
+		}
+		return s.client.CopyToPod(pod, dstInfo.Path, os.Stdin, opts.CopyUIDGID)
+	}
+
+	srcInfo, err := archive.CopyInfoSourcePath(srcPath, opts.FollowLink)
+	if err != nil {
+		return err
+	}
+	srcArchive, err := archive.TarResource(srcInfo)
+	if err != nil {
+		return err
+	}
+	defer srcArchive.Close() //nolint:errcheck
+
+	// see local backend for details on how archive.PrepareArchiveCopy alters the archive to get the desired
+	// copy behavior once extracted in destination directory
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(srcArchive, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close() //nolint:errcheck
+
+	return s.client.CopyToPod(pod, dstDir, preparedArchive, opts.CopyUIDGID)
+}
+
+func (s *composeService) copyFromPod(pod corev1.Pod, srcPath, dstPath string, opts compose.CopyOptions) error {
+	var err error
+	if dstPath != "-" {
+		// Get an absolute destination path.
+		dstPath, err = utils.ResolveLocalPath(dstPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := command.ValidateOutputPath(dstPath); err != nil {
+		return err
+	}
+
+	if utils.HasGlobPattern(srcPath) {
+		content, err := s.copyGlobFromPod(pod, srcPath)
+		if err != nil {
+			return err
+		}
+		defer content.Close() //nolint:errcheck
+
+		if dstPath == "-" {
+			_, err = io.Copy(os.Stdout, content)
+			return err
+		}
+		if err := os.MkdirAll(dstPath, 0755); err != nil {
+			return err
+		}
+		return archive.Untar(content, dstPath, &archive.TarOptions{NoLchown: true})
+	}
+
+	// if client requests to follow symbol link, then must decide target file to be copied
+	var rebaseName string
+	if opts.FollowLink {
+		srcPath, rebaseName = s.followPodLink(pod, srcPath)
+	}
+
+	stat, err := s.client.StatPath(pod, srcPath)
+	if err != nil {
+		return err
+	}
+	if !stat.Exists {
+		return errors.Errorf("no such file or directory \"%s:%s\"", pod.Name, srcPath)
+	}
+
+	content := s.client.CopyFromPod(pod, srcPath)
+	defer content.Close() //nolint:errcheck
+
+	if dstPath == "-" {
+		_, err = io.Copy(os.Stdout, content)
+		return err
+	}
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      stat.IsDir,
+		RebaseName: rebaseName,
+	}
+
+	var preArchive io.ReadCloser = content
+	if len(srcInfo.RebaseName) != 0 {
+		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
+		preArchive = archive.RebaseArchiveEntries(content, srcBase, srcInfo.RebaseName)
+	}
+
+	return archive.CopyTo(preArchive, srcInfo, dstPath)
+}
+
+// getPodDestinationInfo prepares destination copy info by stat-ing the pod container path
+func (s *composeService) getPodDestinationInfo(pod corev1.Pod, dstPath string) (archive.CopyInfo, error) {
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	stat, err := s.client.StatPath(pod, dstPath)
+	if err != nil {
+		return dstInfo, err
+	}
+
+	// If the destination is a symbolic link, we should evaluate it.
+	if stat.LinkTarget != "" {
+		linkTarget := stat.LinkTarget
+		if !path.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = path.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		stat, err = s.client.StatPath(pod, linkTarget)
+		if err != nil {
+			return dstInfo, err
+		}
+	}
+
+	// Assume the parent directory of a missing destination exists, as the local backend does
+	dstInfo.Exists, dstInfo.IsDir = stat.Exists, stat.IsDir
+	return dstInfo, nil
+}
+
+func (s *composeService) followPodLink(pod corev1.Pod, srcPath string) (string, string) {
+	var rebaseName string
+	stat, err := s.client.StatPath(pod, srcPath)
+
+	// If the source is a symbolic link, we should follow it.
+	if err == nil && stat.LinkTarget != "" {
+		linkTarget := stat.LinkTarget
+		if !path.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			srcParent, _ := archive.SplitPathDirEntry(srcPath)
+			linkTarget = path.Join(srcParent, linkTarget)
+		}
+
+		linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+		srcPath = linkTarget
+	}
+	return srcPath, rebaseName
+}
+
+// copyGlobFromPod returns a tar archive with the files from pod container matching srcPath glob pattern
+func (s *composeService) copyGlobFromPod(pod corev1.Pod, srcPath string) (io.ReadCloser, error) {
+	dir, pattern := path.Split(srcPath)
+	if utils.HasGlobPattern(dir) {
+		return nil, errors.Errorf("glob pattern is only supported on the last element of path %q", srcPath)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	stat, err := s.client.StatPath(pod, dir)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.IsDir {
+		return nil, errors.Errorf("%q is not a directory", dir)
+	}
+	return utils.FilterArchive(s.client.CopyFromPod(pod, dir), stat.Name, pattern), nil
+}
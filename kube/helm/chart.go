@@ -113,8 +113,8 @@ func jsonToYaml(j []byte, spaces int) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-// GetChartInMemory get memory representation of helm chart
-func GetChartInMemory(project *types.Project) (*chart.Chart, error) {
+// GetChartInMemory get memory representation of helm chart, images overriding service images by service name
+func GetChartInMemory(project *types.Project, images map[string]string) (*chart.Chart, error) {
 	// replace _ with - in volume names
 	for k, v := range project.Volumes {
 		volumeName := strings.ReplaceAll(k, "_", "-")
@@ -123,7 +123,7 @@ func GetChartInMemory(project *types.Project) (*chart.Chart, error) {
 			delete(project.Volumes, k)
 		}
 	}
-	objects, err := resources.MapToKubernetesObjects(project)
+	objects, err := resources.MapToKubernetesObjects(project, images)
 	if err != nil {
 		return nil, err
 	}
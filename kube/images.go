@@ -0,0 +1,147 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/distribution/distribution/v3/reference"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// Build executes the equivalent to a `compose build`, using local BuildKit to build images tagged for the context registry
+func (s *composeService) Build(ctx context.Context, project *types.Project, options compose.BuildOptions) error {
+	if err := s.setRegistryImages(project); err != nil {
+		return err
+	}
+	return s.local.Build(ctx, project, options)
+}
+
+// Push executes the equivalent ot a `compose push`, to the context registry
+func (s *composeService) Push(ctx context.Context, project *types.Project, options compose.PushOptions) error {
+	if err := s.setRegistryImages(project); err != nil {
+		return err
+	}
+	return s.local.Push(ctx, project, options)
+}
+
+// Pull executes the equivalent of a `compose pull`, to the local engine
+func (s *composeService) Pull(ctx context.Context, project *types.Project, options compose.PullOptions) error {
+	if s.registry != "" {
+		if err := s.setRegistryImages(project); err != nil {
+			return err
+		}
+	}
+	return s.local.Pull(ctx, project, options)
+}
+
+// setRegistryImages names images of services with a build section after the context registry
+func (s *composeService) setRegistryImages(project *types.Project) error {
+	if s.registry == "" {
+		return fmt.Errorf("building images for Kubernetes requires a registry, set by `docker context create kubernetes --registry`")
+	}
+	for i, service := range project.Services {
+		if service.Build == nil {
+			continue
+		}
+		project.Services[i].Image = registryImage(s.registry, project.Name, service)
+	}
+	return nil
+}
+
+// registryImage returns the service image reference in registry
+func registryImage(registry string, projectName string, service types.ServiceConfig) string {
+	image := service.Image
+	if image == "" {
+		image = projectName + "_" + service.Name
+	}
+	if strings.HasPrefix(image, registry+"/") {
+		return image
+	}
+	return registry + "/" + image
+}
+
+// prepareImages builds the missing images of services with a build section, pushes them to the context registry, and
+// returns the digest-pinned references to be deployed. Nothing is built when no registry is configured.
+func (s *composeService) prepareImages(ctx context.Context, project *types.Project) (map[string]string, error) {
+	if s.registry == "" {
+		return nil, nil
+	}
+	var toBuild types.Services
+	var toPush types.Services
+	for _, service := range project.Services {
+		if service.Build == nil {
+			continue
+		}
+		service.Image = registryImage(s.registry, project.Name, service)
+		toPush = append(toPush, service)
+		_, _, err := s.apiClient.ImageInspectWithRaw(ctx, service.Image)
+		if err != nil || service.PullPolicy == types.PullPolicyBuild {
+			toBuild = append(toBuild, service)
+		}
+	}
+	if len(toPush) == 0 {
+		return nil, nil
+	}
+
+	if len(toBuild) > 0 {
+		buildProject := *project
+		buildProject.Services = toBuild
+		if err := s.local.Build(ctx, &buildProject, compose.BuildOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	images := map[string]string{}
+	pushProject := *project
+	pushProject.Services = toPush
+	var pinErr error
+	err := s.local.Push(ctx, &pushProject, compose.PushOptions{
+		Summary: func(summary compose.PushSummary) {
+			pinned, err := pinImage(summary.Image, summary.Digest)
+			if err != nil {
+				pinErr = err
+				return
+			}
+			images[summary.Service] = pinned
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, pinErr
+}
+
+// pinImage returns the image reference pinned to digest
+func pinImage(image string, dgst string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+	pinned, err := reference.WithDigest(reference.TrimNamed(named), digest.Digest(dgst))
+	if err != nil {
+		return "", err
+	}
+	return reference.FamiliarString(pinned), nil
+}
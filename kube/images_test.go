@@ -0,0 +1,61 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestRegistryImage(t *testing.T) {
+	assert.Equal(t, registryImage("registry.example.com/team", "demo", types.ServiceConfig{Name: "app"}), "registry.example.com/team/demo_app")
+	assert.Equal(t, registryImage("registry.example.com/team", "demo", types.ServiceConfig{Name: "app", Image: "app:1.0"}), "registry.example.com/team/app:1.0")
+	assert.Equal(t, registryImage("registry.example.com/team", "demo", types.ServiceConfig{Name: "app", Image: "registry.example.com/team/app"}), "registry.example.com/team/app")
+}
+
+func TestSetRegistryImages(t *testing.T) {
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "app", Build: &types.BuildConfig{Context: "."}},
+			{Name: "db", Image: "postgres"},
+		},
+	}
+	s := composeService{}
+	err := s.setRegistryImages(project)
+	assert.ErrorContains(t, err, "requires a registry")
+
+	s.registry = "registry.example.com/team"
+	err = s.setRegistryImages(project)
+	assert.NilError(t, err)
+	assert.Equal(t, project.Services[0].Image, "registry.example.com/team/demo_app")
+	assert.Equal(t, project.Services[1].Image, "postgres")
+}
+
+func TestPinImage(t *testing.T) {
+	dgst := "sha256:0123456789012345678901234567890123456789012345678901234567890123"
+	pinned, err := pinImage("registry.example.com/team/demo_app:latest", dgst)
+	assert.NilError(t, err)
+	assert.Equal(t, pinned, "registry.example.com/team/demo_app@"+dgst)
+
+	_, err = pinImage("registry.example.com/team/demo_app", "invalid")
+	assert.ErrorContains(t, err, "invalid")
+}
@@ -0,0 +1,141 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/types"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressExtension exposes a service published HTTP port through an Ingress
+const IngressExtension = "x-kubernetes-ingress"
+
+// IngressConfig is the service x-kubernetes-ingress extension
+type IngressConfig struct {
+	// Host the Ingress rule matches, any host if empty
+	Host string `json:"host,omitempty"`
+	// Path prefix the Ingress rule matches, defaults to /
+	Path string `json:"path,omitempty"`
+	// TLSSecret is the name of the Secret holding the TLS certificate for host
+	TLSSecret string `json:"tls_secret,omitempty"`
+	// Class is the IngressClass handling the Ingress
+	Class string `json:"class,omitempty"`
+	// Port is the target port traffic is routed to, defaults to the first published port
+	Port uint32 `json:"port,omitempty"`
+}
+
+func getIngressConfig(service types.ServiceConfig) (*IngressConfig, error) {
+	x, ok := service.Extensions[IngressExtension]
+	if !ok {
+		return nil, nil
+	}
+	b, err := json.Marshal(x)
+	if err != nil {
+		return nil, err
+	}
+	var config IngressConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("service %s: invalid %s: %w", service.Name, IngressExtension, err)
+	}
+	if config.Path == "" {
+		config.Path = "/"
+	}
+	return &config, nil
+}
+
+// ingressPort returns the service published port routed by its Ingress, if any
+func ingressPort(service types.ServiceConfig, config IngressConfig) *types.ServicePortConfig {
+	for _, p := range service.Ports {
+		p := p
+		if p.Published == 0 || (p.Protocol != "" && p.Protocol != "tcp") {
+			continue
+		}
+		if config.Port == 0 || config.Port == p.Target {
+			return &p
+		}
+	}
+	return nil
+}
+
+func mapToIngress(project *types.Project, service types.ServiceConfig) (*networking.Ingress, error) {
+	config, err := getIngressConfig(service)
+	if err != nil || config == nil {
+		return nil, err
+	}
+
+	port := ingressPort(service, *config)
+	if port == nil {
+		if config.Port != 0 {
+			return nil, fmt.Errorf("service %s: %s port %d is not published", service.Name, IngressExtension, config.Port)
+		}
+		return nil, fmt.Errorf("service %s: %s requires a published port", service.Name, IngressExtension)
+	}
+
+	pathType := networking.PathTypePrefix
+	ingress := &networking.Ingress{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:   service.Name,
+			Labels: selectorLabels(project.Name, service.Name),
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: config.Host,
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path:     config.Path,
+									PathType: &pathType,
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: service.Name,
+											Port: networking.ServiceBackendPort{
+												Number: int32(port.Published),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if config.Class != "" {
+		ingress.Spec.IngressClassName = &config.Class
+	}
+	if config.TLSSecret != "" {
+		tls := networking.IngressTLS{SecretName: config.TLSSecret}
+		if config.Host != "" {
+			tls.Hosts = []string{config.Host}
+		}
+		ingress.Spec.TLS = []networking.IngressTLS{tls}
+	}
+	return ingress, nil
+}
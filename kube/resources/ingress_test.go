@@ -0,0 +1,112 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+)
+
+func TestMapToIngress(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  web:
+    image: nginx
+    ports:
+      - "8443:443"
+      - "8080:80"
+    x-kubernetes-ingress:
+      host: web.example.com
+      path: /app
+      tls_secret: web-tls
+      class: nginx
+      port: 80
+  db:
+    image: postgres
+`)
+	assert.NilError(t, err)
+
+	objects, err := MapToKubernetesObjects(model, nil)
+	assert.NilError(t, err)
+	_, ok := objects["db-ingress.yaml"]
+	assert.Assert(t, !ok)
+
+	ingress := objects["web-ingress.yaml"].(*networking.Ingress)
+	assert.Equal(t, *ingress.Spec.IngressClassName, "nginx")
+	assert.DeepEqual(t, ingress.Spec.TLS, []networking.IngressTLS{{Hosts: []string{"web.example.com"}, SecretName: "web-tls"}})
+	assert.Equal(t, len(ingress.Spec.Rules), 1)
+	rule := ingress.Spec.Rules[0]
+	assert.Equal(t, rule.Host, "web.example.com")
+	assert.Equal(t, rule.HTTP.Paths[0].Path, "/app")
+	assert.Equal(t, *rule.HTTP.Paths[0].PathType, networking.PathTypePrefix)
+	assert.Equal(t, rule.HTTP.Paths[0].Backend.Service.Name, "web")
+	assert.Equal(t, rule.HTTP.Paths[0].Backend.Service.Port.Number, int32(8080))
+
+	// the routed port is only reachable through the Ingress, other published ports through a LoadBalancer
+	service := objects["web-service.yaml"].(*core.Service)
+	assert.Equal(t, service.Spec.Type, core.ServiceTypeClusterIP)
+	assert.Equal(t, len(service.Spec.Ports), 2)
+	published := objects["web-published-service.yaml"].(*core.Service)
+	assert.Equal(t, published.Name, "web-published")
+	assert.Equal(t, published.Spec.Type, core.ServiceTypeLoadBalancer)
+	assert.Equal(t, len(published.Spec.Ports), 1)
+	assert.Equal(t, published.Spec.Ports[0].Port, int32(8443))
+	assert.DeepEqual(t, published.Spec.Selector, service.Spec.Selector)
+}
+
+func TestMapToIngressDefaults(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  web:
+    image: nginx
+    ports:
+      - "8080:80"
+    x-kubernetes-ingress: {}
+`)
+	assert.NilError(t, err)
+
+	ingress, err := mapToIngress(model, model.Services[0])
+	assert.NilError(t, err)
+	assert.Equal(t, mapToService(model, model.Services[0]).Spec.Type, core.ServiceTypeClusterIP)
+	assert.Assert(t, mapToPublishedService(model, model.Services[0]) == nil)
+	assert.Assert(t, ingress.Spec.IngressClassName == nil)
+	assert.Assert(t, ingress.Spec.TLS == nil)
+	assert.Equal(t, ingress.Spec.Rules[0].Host, "")
+	assert.Equal(t, ingress.Spec.Rules[0].HTTP.Paths[0].Path, "/")
+	assert.Equal(t, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number, int32(8080))
+}
+
+func TestMapToIngressRequiresPublishedPort(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  web:
+    image: nginx
+    expose:
+      - "80"
+    x-kubernetes-ingress:
+      host: web.example.com
+`)
+	assert.NilError(t, err)
+
+	_, err = mapToIngress(model, model.Services[0])
+	assert.Error(t, err, "service web: x-kubernetes-ingress requires a published port")
+}
@@ -38,8 +38,8 @@ const (
 	clusterIPHeadless = "None"
 )
 
-//MapToKubernetesObjects maps compose project to Kubernetes objects
-func MapToKubernetesObjects(project *types.Project) (map[string]runtime.Object, error) {
+//MapToKubernetesObjects maps compose project to Kubernetes objects, images overriding service images by service name
+func MapToKubernetesObjects(project *types.Project, images map[string]string) (map[string]runtime.Object, error) {
 	objects := map[string]runtime.Object{}
 
 	secrets, err := toSecretSpecs(project)
@@ -53,21 +53,55 @@ func MapToKubernetesObjects(project *types.Project) (map[string]runtime.Object,
 		}
 	}
 
+	for name, policy := range mapToNetworkPolicies(project) {
+		objects[fmt.Sprintf("%s-networkpolicy.yaml", name)] = policy
+	}
+
 	for _, service := range project.Services {
+		if image, ok := images[service.Name]; ok {
+			service.Image = image
+		}
 		svcObject := mapToService(project, service)
 		if svcObject != nil {
 			objects[fmt.Sprintf("%s-service.yaml", service.Name)] = svcObject
 		} else {
 			log.Println("Missing port mapping from service config.")
 		}
+		if published := mapToPublishedService(project, service); published != nil {
+			objects[fmt.Sprintf("%s-service.yaml", published.Name)] = published
+		}
+
+		if policy := mapToPublishedPortsPolicy(project, service); policy != nil {
+			objects[fmt.Sprintf("%s-networkpolicy.yaml", policy.Name)] = policy
+		}
 
-		if service.Deploy != nil && service.Deploy.Mode == "global" {
+		ingress, err := mapToIngress(project, service)
+		if err != nil {
+			return nil, err
+		}
+		if ingress != nil {
+			objects[fmt.Sprintf("%s-ingress.yaml", service.Name)] = ingress
+		}
+
+		switch {
+		case service.Deploy != nil && service.Deploy.Mode == "global":
 			daemonset, err := mapToDaemonset(project, service)
 			if err != nil {
 				return nil, err
 			}
 			objects[fmt.Sprintf("%s-daemonset.yaml", service.Name)] = daemonset
-		} else {
+		case isStateful(service):
+			statefulset, err := mapToStatefulSet(project, service)
+			if err != nil {
+				return nil, err
+			}
+			objects[fmt.Sprintf("%s-statefulset.yaml", service.Name)] = statefulset
+			if headless := mapToHeadlessService(project, service); headless != nil {
+				objects[fmt.Sprintf("%s-service.yaml", headless.Name)] = headless
+			}
+			// claims are created per pod from the StatefulSet templates
+			continue
+		default:
 			deployment, err := mapToDeployment(project, service)
 			if err != nil {
 				return nil, err
@@ -84,21 +118,21 @@ func MapToKubernetesObjects(project *types.Project) (map[string]runtime.Object,
 	return objects, nil
 }
 
+// PublishedServiceSuffix names the LoadBalancer Service exposing the published ports of a service which aren't routed
+// by its Ingress
+const PublishedServiceSuffix = "-published"
+
 func mapToService(project *types.Project, service types.ServiceConfig) *core.Service {
+	routed := routedPort(service)
 	ports := []core.ServicePort{}
 	serviceType := core.ServiceTypeClusterIP
 	clusterIP := ""
 	for _, p := range service.Ports {
-		if p.Published != 0 {
+		if p.Published != 0 && routed == nil {
+			// an Ingress makes published ports reachable, a LoadBalancer would only duplicate it
 			serviceType = core.ServiceTypeLoadBalancer
 		}
-		ports = append(ports,
-			core.ServicePort{
-				Name:       fmt.Sprintf("%d-%s", p.Published, strings.ToLower(p.Protocol)),
-				Port:       int32(p.Published),
-				TargetPort: intstr.FromInt(int(p.Target)),
-				Protocol:   toProtocol(p.Protocol),
-			})
+		ports = append(ports, toServicePort(p))
 	}
 	if len(ports) == 0 { // headless service
 		clusterIP = clusterIPHeadless
@@ -113,13 +147,65 @@ func mapToService(project *types.Project, service types.ServiceConfig) *core.Ser
 		},
 		Spec: core.ServiceSpec{
 			ClusterIP: clusterIP,
-			Selector:  selectorLabels(project.Name, service.Name),
+			Selector:  servicePodLabels(project.Name, service.Name),
 			Ports:     ports,
 			Type:      serviceType,
 		},
 	}
 }
 
+// mapToPublishedService exposes the published ports of a service its Ingress doesn't route through a LoadBalancer
+// Service, as the service main Service is then a ClusterIP one
+func mapToPublishedService(project *types.Project, service types.ServiceConfig) *core.Service {
+	routed := routedPort(service)
+	if routed == nil {
+		return nil
+	}
+	var ports []core.ServicePort
+	for _, p := range service.Ports {
+		if p.Published == 0 || (p.Target == routed.Target && p.Published == routed.Published && p.Protocol == routed.Protocol) {
+			continue
+		}
+		ports = append(ports, toServicePort(p))
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return &core.Service{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name: service.Name + PublishedServiceSuffix,
+		},
+		Spec: core.ServiceSpec{
+			Selector: servicePodLabels(project.Name, service.Name),
+			Ports:    ports,
+			Type:     core.ServiceTypeLoadBalancer,
+		},
+	}
+}
+
+// routedPort returns the service published port routed by its Ingress, if any. Invalid Ingress configurations are
+// reported when mapping the Ingress.
+func routedPort(service types.ServiceConfig) *types.ServicePortConfig {
+	config, err := getIngressConfig(service)
+	if err != nil || config == nil {
+		return nil
+	}
+	return ingressPort(service, *config)
+}
+
+func toServicePort(p types.ServicePortConfig) core.ServicePort {
+	return core.ServicePort{
+		Name:       fmt.Sprintf("%d-%s", p.Published, strings.ToLower(p.Protocol)),
+		Port:       int32(p.Published),
+		TargetPort: intstr.FromInt(int(p.Target)),
+		Protocol:   toProtocol(p.Protocol),
+	}
+}
+
 func mapToDeployment(project *types.Project, service types.ServiceConfig) (*apps.Deployment, error) {
 	labels := selectorLabels(project.Name, service.Name)
 	selector := new(meta.LabelSelector)
@@ -127,7 +213,7 @@ func mapToDeployment(project *types.Project, service types.ServiceConfig) (*apps
 	for key, val := range labels {
 		selector.MatchLabels[key] = val
 	}
-	podTemplate, err := toPodTemplate(project, service, labels)
+	podTemplate, err := toPodTemplate(project, service, servicePodLabels(project.Name, service.Name))
 	if err != nil {
 		return nil, err
 	}
@@ -156,9 +242,17 @@ func selectorLabels(projectName string, serviceName string) map[string]string {
 	}
 }
 
+// servicePodLabels are set on service pods and select them, but not one-off pods run for the same service. Workload
+// selectors keep to selectorLabels, as they can't be changed on existing objects.
+func servicePodLabels(projectName string, serviceName string) map[string]string {
+	labels := selectorLabels(projectName, serviceName)
+	labels[compose.OneoffLabel] = "False"
+	return labels
+}
+
 func mapToDaemonset(project *types.Project, service types.ServiceConfig) (*apps.DaemonSet, error) {
 	labels := selectorLabels(project.Name, service.Name)
-	podTemplate, err := toPodTemplate(project, service, labels)
+	podTemplate, err := toPodTemplate(project, service, servicePodLabels(project.Name, service.Name))
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +307,10 @@ func mapToPVC(project *types.Project, service types.ServiceConfig, vol types.Ser
 		ObjectMeta: meta.ObjectMeta{
 			Name:   vol.Source,
 			Labels: selectorLabels(project.Name, service.Name),
+			Annotations: map[string]string{
+				// volumes outlive the project, as with Docker, until `down --volumes`
+				"helm.sh/resource-policy": "keep",
+			},
 		},
 		Spec: core.PersistentVolumeClaimSpec{
 			VolumeName:  vol.Source,
@@ -23,6 +23,7 @@ import (
 
 	"gotest.tools/v3/assert"
 
+	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -48,7 +49,7 @@ services:
 			Name: "nginx",
 		},
 		Spec: core.ServiceSpec{
-			Selector: map[string]string{"com.docker.compose.service": "nginx", "com.docker.compose.project": ""},
+			Selector: map[string]string{"com.docker.compose.service": "nginx", "com.docker.compose.project": "", "com.docker.compose.oneoff": "False"},
 			Ports: []core.ServicePort{
 				{
 					Name:       "80-tcp",
@@ -79,9 +80,56 @@ services:
 			Name: "nginx",
 		},
 		Spec: core.ServiceSpec{
-			Selector:  map[string]string{"com.docker.compose.service": "nginx", "com.docker.compose.project": ""},
+			Selector:  map[string]string{"com.docker.compose.service": "nginx", "com.docker.compose.project": "", "com.docker.compose.oneoff": "False"},
 			ClusterIP: "None",
 			Ports:     []core.ServicePort{},
 			Type:      core.ServiceTypeClusterIP,
 		}})
 }
+
+func TestMapToKubernetesObjectsPinnedImages(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  app:
+    build: .
+    image: registry.example.com/team/app
+  db:
+    image: postgres
+`)
+	assert.NilError(t, err)
+
+	objects, err := MapToKubernetesObjects(model, map[string]string{
+		"app": "registry.example.com/team/app@sha256:0123456789012345678901234567890123456789012345678901234567890123",
+	})
+	assert.NilError(t, err)
+
+	app := objects["app-deployment.yaml"].(*apps.Deployment)
+	assert.Equal(t, app.Spec.Template.Spec.Containers[0].Image, "registry.example.com/team/app@sha256:0123456789012345678901234567890123456789012345678901234567890123")
+	db := objects["db-deployment.yaml"].(*apps.Deployment)
+	assert.Equal(t, db.Spec.Template.Spec.Containers[0].Image, "postgres")
+}
+
+func TestDeploymentPodsExcludeOneOff(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  nginx:
+    image: nginx
+`)
+	assert.NilError(t, err)
+
+	deployment, err := mapToDeployment(model, model.Services[0])
+	assert.NilError(t, err)
+	// selector is immutable, so it must not change for existing deployments
+	assert.DeepEqual(t, deployment.Spec.Selector.MatchLabels, map[string]string{"com.docker.compose.service": "nginx", "com.docker.compose.project": ""})
+	assert.Equal(t, deployment.Spec.Template.Labels["com.docker.compose.oneoff"], "False")
+
+	job, err := MapToOneOffJob(model, model.Services[0], "nginx-run")
+	assert.NilError(t, err)
+	service := mapToService(model, model.Services[0])
+	for key, value := range service.Spec.Selector {
+		if job.Spec.Template.Labels[key] != value {
+			return
+		}
+	}
+	t.Fatal("one-off pods must not be selected by the service")
+}
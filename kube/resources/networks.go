@@ -0,0 +1,130 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// NetworkLabelPrefix prefixes the pod labels telling which compose networks the pod is attached to
+const NetworkLabelPrefix = "com.docker.compose.network/"
+
+// networkName returns the name of a project network, which is also used by other projects for external networks
+func networkName(project *types.Project, key string) string {
+	if network, ok := project.Networks[key]; ok && network.Name != "" {
+		return network.Name
+	}
+	return key
+}
+
+// networkLabels returns the labels of a service pod for the networks it is attached to
+func networkLabels(project *types.Project, service types.ServiceConfig) map[string]string {
+	labels := map[string]string{}
+	for key := range service.Networks {
+		labels[NetworkLabelPrefix+networkName(project, key)] = "true"
+	}
+	return labels
+}
+
+// mapToNetworkPolicies restricts pods ingress to the pods sharing a network with them, as compose networks do.
+// NetworkPolicies add up, so a pod attached to multiple networks accepts traffic from all of them. As the cluster
+// network plugin enforces policies, this is a no-op on clusters without NetworkPolicy support.
+func mapToNetworkPolicies(project *types.Project) map[string]*networking.NetworkPolicy {
+	policies := map[string]*networking.NetworkPolicy{}
+	for _, service := range project.Services {
+		for key := range service.Networks {
+			name := fmt.Sprintf("%s-%s-network", toDNSName(project.Name), toDNSName(key))
+			if _, ok := policies[name]; ok {
+				continue
+			}
+			selector := meta.LabelSelector{
+				MatchLabels: map[string]string{NetworkLabelPrefix + networkName(project, key): "true"},
+			}
+			policies[name] = &networking.NetworkPolicy{
+				TypeMeta: meta.TypeMeta{
+					Kind:       "NetworkPolicy",
+					APIVersion: "networking.k8s.io/v1",
+				},
+				ObjectMeta: meta.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{compose.ProjectLabel: project.Name},
+				},
+				Spec: networking.NetworkPolicySpec{
+					PodSelector: selector,
+					PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+					Ingress: []networking.NetworkPolicyIngressRule{
+						{
+							From: []networking.NetworkPolicyPeer{{PodSelector: &selector}},
+						},
+					},
+				},
+			}
+		}
+	}
+	return policies
+}
+
+// mapToPublishedPortsPolicy lets published ports of a service be reached from anywhere, so the traffic its
+// LoadBalancer Service or Ingress routes isn't blocked by the network policies
+func mapToPublishedPortsPolicy(project *types.Project, service types.ServiceConfig) *networking.NetworkPolicy {
+	var ports []networking.NetworkPolicyPort
+	for _, p := range service.Ports {
+		if p.Published == 0 {
+			continue
+		}
+		protocol := toProtocol(p.Protocol)
+		port := intstr.FromInt(int(p.Target))
+		ports = append(ports, networking.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return &networking.NetworkPolicy{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:   fmt.Sprintf("%s-published", toDNSName(service.Name)),
+			Labels: selectorLabels(project.Name, service.Name),
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: meta.LabelSelector{MatchLabels: selectorLabels(project.Name, service.Name)},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+			Ingress: []networking.NetworkPolicyIngressRule{
+				{Ports: ports},
+			},
+		},
+	}
+}
+
+func toDNSName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
@@ -0,0 +1,147 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const networksYAML = `
+services:
+  web:
+    image: nginx
+    ports:
+      - "8080:80"
+    networks:
+      - front
+  api:
+    image: api
+    networks:
+      - front
+      - back
+  db:
+    image: postgres
+    networks:
+      - back
+  worker:
+    image: worker
+networks:
+  front:
+  back:
+`
+
+func TestNetworkLabels(t *testing.T) {
+	model, err := loadYAML(networksYAML)
+	assert.NilError(t, err)
+	model.Name = "myproject"
+
+	objects, err := MapToKubernetesObjects(model, nil)
+	assert.NilError(t, err)
+
+	api := objects["api-deployment.yaml"].(*apps.Deployment)
+	assert.Equal(t, api.Spec.Template.Labels[NetworkLabelPrefix+networkName(model, "front")], "true")
+	assert.Equal(t, api.Spec.Template.Labels[NetworkLabelPrefix+networkName(model, "back")], "true")
+	// network labels don't change the Deployment selector
+	_, ok := api.Spec.Selector.MatchLabels[NetworkLabelPrefix+networkName(model, "front")]
+	assert.Assert(t, !ok)
+
+	db := objects["db-deployment.yaml"].(*apps.Deployment)
+	_, ok = db.Spec.Template.Labels[NetworkLabelPrefix+networkName(model, "front")]
+	assert.Assert(t, !ok)
+}
+
+func TestMapToNetworkPolicies(t *testing.T) {
+	model, err := loadYAML(networksYAML)
+	assert.NilError(t, err)
+	model.Name = "myproject"
+
+	policies := mapToNetworkPolicies(model)
+	assert.Equal(t, len(policies), 3)
+
+	front := policies["myproject-front-network"]
+	selector := meta.LabelSelector{
+		MatchLabels: map[string]string{NetworkLabelPrefix + networkName(model, "front"): "true"},
+	}
+	assert.DeepEqual(t, front.Spec, networking.NetworkPolicySpec{
+		PodSelector: selector,
+		PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		Ingress: []networking.NetworkPolicyIngressRule{
+			{From: []networking.NetworkPolicyPeer{{PodSelector: &selector}}},
+		},
+	})
+	_, ok := policies["myproject-back-network"]
+	assert.Assert(t, ok)
+	// worker has no networks declared, so it is attached to the default network
+	_, ok = policies["myproject-default-network"]
+	assert.Assert(t, ok)
+}
+
+func TestExternalNetworkPolicy(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  web:
+    image: nginx
+    networks:
+      - shared
+networks:
+  shared:
+    external: true
+    name: shared_network
+`)
+	assert.NilError(t, err)
+
+	objects, err := MapToKubernetesObjects(model, nil)
+	assert.NilError(t, err)
+	// pods of other projects attached to the external network share the same label
+	web := objects["web-deployment.yaml"].(*apps.Deployment)
+	assert.Equal(t, web.Spec.Template.Labels[NetworkLabelPrefix+"shared_network"], "true")
+	for _, o := range objects {
+		if policy, ok := o.(*networking.NetworkPolicy); ok {
+			assert.DeepEqual(t, policy.Spec.PodSelector.MatchLabels, map[string]string{NetworkLabelPrefix + "shared_network": "true"})
+		}
+	}
+}
+
+func TestPublishedPortsPolicy(t *testing.T) {
+	model, err := loadYAML(networksYAML)
+	assert.NilError(t, err)
+	model.Name = "myproject"
+
+	web, err := model.GetService("web")
+	assert.NilError(t, err)
+	policy := mapToPublishedPortsPolicy(model, web)
+	protocol := core.ProtocolTCP
+	port := intstr.FromInt(80)
+	assert.Equal(t, policy.Name, "web-published")
+	assert.DeepEqual(t, policy.Spec.PodSelector.MatchLabels, selectorLabels("myproject", "web"))
+	assert.DeepEqual(t, policy.Spec.Ingress, []networking.NetworkPolicyIngressRule{
+		{Ports: []networking.NetworkPolicyPort{{Protocol: &protocol, Port: &port}}},
+	})
+
+	api, err := model.GetService("api")
+	assert.NilError(t, err)
+	assert.Assert(t, mapToPublishedPortsPolicy(model, api) == nil)
+}
@@ -0,0 +1,55 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/compose-spec/compose-go/types"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// MapToOneOffJob maps a service to a Job running a single one-off pod, as `compose run` does.
+// A Job is used rather than a bare Pod so the pod has a controller and won't get adopted by the service Deployment.
+func MapToOneOffJob(project *types.Project, service types.ServiceConfig, name string) (*batch.Job, error) {
+	labels := selectorLabels(project.Name, service.Name)
+	labels[compose.OneoffLabel] = "True"
+	podTemplate, err := toPodTemplate(project, service, labels)
+	if err != nil {
+		return nil, err
+	}
+	podTemplate.Spec.RestartPolicy = core.RestartPolicyNever
+	backoffLimit := int32(0)
+	return &batch.Job{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: batch.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template:     podTemplate,
+		},
+	}, nil
+}
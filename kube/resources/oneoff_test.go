@@ -0,0 +1,49 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	core "k8s.io/api/core/v1"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestMapToOneOffJob(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  app:
+    image: alpine
+    command: echo hello
+`)
+	assert.NilError(t, err)
+	service, err := model.GetService("app")
+	assert.NilError(t, err)
+
+	job, err := MapToOneOffJob(model, service, "myproject-app-run-abcde")
+	assert.NilError(t, err)
+	assert.Equal(t, job.Name, "myproject-app-run-abcde")
+	assert.Equal(t, *job.Spec.BackoffLimit, int32(0))
+	assert.Equal(t, job.Spec.Template.Spec.RestartPolicy, core.RestartPolicyNever)
+	assert.Equal(t, job.Spec.Template.Labels[compose.OneoffLabel], "True")
+	assert.Equal(t, job.Spec.Template.Labels[compose.ServiceLabel], "app")
+	assert.DeepEqual(t, job.Spec.Template.Spec.Containers[0].Args, []string{"echo", "hello"})
+}
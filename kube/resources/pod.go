@@ -80,8 +80,12 @@ func toPodTemplate(project *types.Project, serviceConfig types.ServiceConfig, la
 		if err != nil {
 			return apiv1.PodTemplateSpec{}, err
 		} */
+	podLabels := networkLabels(project, serviceConfig)
+	for k, v := range labels {
+		podLabels[k] = v
+	}
 	tpl.ObjectMeta = metav1.ObjectMeta{
-		Labels:      labels,
+		Labels:      podLabels,
 		Annotations: serviceConfig.Labels,
 	}
 	tpl.Spec.RestartPolicy = restartPolicy
@@ -0,0 +1,171 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// StatefulSetExtension marks a service with named volumes to be deployed as a StatefulSet, even when not scaled
+const StatefulSetExtension = "x-kubernetes-statefulset"
+
+// isStateful tells if a service is deployed as a StatefulSet, so each replica gets its own claim for named volumes
+func isStateful(service types.ServiceConfig) bool {
+	if service.Deploy != nil && service.Deploy.Mode == "global" {
+		return false
+	}
+	if len(namedVolumes(service)) == 0 {
+		return false
+	}
+	if marked, ok := service.Extensions[StatefulSetExtension].(bool); ok {
+		return marked
+	}
+	return *toReplicas(service.Deploy) > 1
+}
+
+func namedVolumes(service types.ServiceConfig) []types.ServiceVolumeConfig {
+	var volumes []types.ServiceVolumeConfig
+	for _, vol := range service.Volumes {
+		if vol.Type == "volume" && vol.Source != "" {
+			vol.Source = strings.ReplaceAll(vol.Source, "_", "-")
+			volumes = append(volumes, vol)
+		}
+	}
+	return volumes
+}
+
+// headlessServiceName is the name of the Service governing the StatefulSet pods network identity
+func headlessServiceName(project *types.Project, service types.ServiceConfig) string {
+	if svc := mapToService(project, service); svc.Spec.ClusterIP == clusterIPHeadless {
+		return service.Name
+	}
+	return fmt.Sprintf("%s-headless", service.Name)
+}
+
+// mapToHeadlessService returns the headless Service governing the StatefulSet, unless the service one already is
+func mapToHeadlessService(project *types.Project, service types.ServiceConfig) *core.Service {
+	name := headlessServiceName(project, service)
+	if name == service.Name {
+		return nil
+	}
+	ports := []core.ServicePort{}
+	for _, p := range service.Ports {
+		ports = append(ports, core.ServicePort{
+			Name:       fmt.Sprintf("%d-%s", p.Target, strings.ToLower(p.Protocol)),
+			Port:       int32(p.Target),
+			TargetPort: intstr.FromInt(int(p.Target)),
+			Protocol:   toProtocol(p.Protocol),
+		})
+	}
+	return &core.Service{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name: name,
+		},
+		Spec: core.ServiceSpec{
+			ClusterIP: clusterIPHeadless,
+			Selector:  servicePodLabels(project.Name, service.Name),
+			Ports:     ports,
+			Type:      core.ServiceTypeClusterIP,
+		},
+	}
+}
+
+func mapToStatefulSet(project *types.Project, service types.ServiceConfig) (*apps.StatefulSet, error) {
+	labels := selectorLabels(project.Name, service.Name)
+	selector := new(meta.LabelSelector)
+	selector.MatchLabels = make(map[string]string)
+	for key, val := range labels {
+		selector.MatchLabels[key] = val
+	}
+	podTemplate, err := toPodTemplate(project, service, servicePodLabels(project.Name, service.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	// named volumes are bound to the pod claims created from the templates rather than to pod volumes
+	var claims []core.PersistentVolumeClaim
+	claimed := map[string]bool{}
+	for _, vol := range namedVolumes(service) {
+		if claimed[vol.Source] {
+			continue
+		}
+		claimed[vol.Source] = true
+		claim := mapToPVC(project, service, vol).(*core.PersistentVolumeClaim)
+		claim.TypeMeta = meta.TypeMeta{}
+		claim.Annotations = nil
+		claim.Spec.VolumeName = ""
+		claims = append(claims, *claim)
+	}
+	var volumes []core.Volume
+	for _, v := range podTemplate.Spec.Volumes {
+		if !claimed[v.Name] {
+			volumes = append(volumes, v)
+		}
+	}
+	podTemplate.Spec.Volumes = volumes
+
+	podManagementPolicy, updateStrategy := toStatefulSetStrategy(service.Deploy)
+	return &apps.StatefulSet{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:   service.Name,
+			Labels: labels,
+		},
+		Spec: apps.StatefulSetSpec{
+			Selector:             selector,
+			Replicas:             toReplicas(service.Deploy),
+			ServiceName:          headlessServiceName(project, service),
+			PodManagementPolicy:  podManagementPolicy,
+			UpdateStrategy:       updateStrategy,
+			Template:             podTemplate,
+			VolumeClaimTemplates: claims,
+		},
+	}, nil
+}
+
+// toStatefulSetStrategy maps update_config to an ordered rollout, replacing pods one at a time from the highest
+// ordinal. As StatefulSets can't update more than one pod at once, a parallelism other than 1 only lets pods be
+// created and deleted in parallel when scaling.
+func toStatefulSetStrategy(deploy *types.DeployConfig) (apps.PodManagementPolicyType, apps.StatefulSetUpdateStrategy) {
+	policy := apps.OrderedReadyPodManagement
+	if deploy != nil && deploy.UpdateConfig != nil && deploy.UpdateConfig.Parallelism != nil && *deploy.UpdateConfig.Parallelism != 1 {
+		policy = apps.ParallelPodManagement
+	}
+	partition := int32(0)
+	return policy, apps.StatefulSetUpdateStrategy{
+		Type: apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+}
@@ -0,0 +1,105 @@
+// +build kube
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+)
+
+func TestScaledServiceWithNamedVolumeIsStatefulSet(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  db:
+    image: postgres
+    ports:
+      - "5432:5432"
+    volumes:
+      - db_data:/var/lib/postgresql/data
+    deploy:
+      replicas: 3
+      update_config:
+        parallelism: 2
+volumes:
+  db_data:
+`)
+	assert.NilError(t, err)
+
+	objects, err := MapToKubernetesObjects(model, nil)
+	assert.NilError(t, err)
+	_, ok := objects["db-deployment.yaml"]
+	assert.Assert(t, !ok)
+	_, ok = objects["db-data-persistentvolumeclaim.yaml"]
+	assert.Assert(t, !ok)
+
+	statefulset := objects["db-statefulset.yaml"].(*apps.StatefulSet)
+	assert.Equal(t, *statefulset.Spec.Replicas, int32(3))
+	assert.Equal(t, statefulset.Spec.ServiceName, "db-headless")
+	assert.Equal(t, statefulset.Spec.PodManagementPolicy, apps.ParallelPodManagement)
+	assert.Equal(t, statefulset.Spec.UpdateStrategy.Type, apps.RollingUpdateStatefulSetStrategyType)
+	assert.Equal(t, len(statefulset.Spec.VolumeClaimTemplates), 1)
+	assert.Equal(t, statefulset.Spec.VolumeClaimTemplates[0].Name, "db-data")
+	assert.Equal(t, statefulset.Spec.Template.Spec.Containers[0].VolumeMounts[0].Name, "db-data")
+	for _, v := range statefulset.Spec.Template.Spec.Volumes {
+		assert.Assert(t, v.Name != "db-data")
+	}
+
+	headless := objects["db-headless-service.yaml"].(*core.Service)
+	assert.Equal(t, headless.Spec.ClusterIP, "None")
+	assert.Equal(t, headless.Spec.Ports[0].Port, int32(5432))
+	_, ok = objects["db-service.yaml"]
+	assert.Assert(t, ok)
+}
+
+func TestStatefulSetExtension(t *testing.T) {
+	model, err := loadYAML(`
+services:
+  db:
+    image: postgres
+    volumes:
+      - data:/data
+    x-kubernetes-statefulset: true
+  cache:
+    image: redis
+    volumes:
+      - cache:/data
+volumes:
+  data:
+  cache:
+`)
+	assert.NilError(t, err)
+
+	objects, err := MapToKubernetesObjects(model, nil)
+	assert.NilError(t, err)
+	statefulset := objects["db-statefulset.yaml"].(*apps.StatefulSet)
+	// db service has no ports, so it is already headless
+	assert.Equal(t, statefulset.Spec.ServiceName, "db")
+	assert.Equal(t, statefulset.Spec.PodManagementPolicy, apps.OrderedReadyPodManagement)
+	_, ok := objects["db-headless-service.yaml"]
+	assert.Assert(t, !ok)
+
+	// a single replica keeps using a Deployment
+	_, ok = objects["cache-deployment.yaml"]
+	assert.Assert(t, ok)
+	_, ok = objects["cache-persistentvolumeclaim.yaml"]
+	assert.Assert(t, ok)
+}
@@ -78,11 +78,19 @@ func (s *composeService) attachContainer(ctx context.Context, container moby.Con
 			Line:      line,
 		})
 	})
-	_, err = s.attachContainerStreams(ctx, container.ID, service.Tty, nil, w)
+	errWriter := utils.GetWriter(func(line string) {
+		listener(compose.ContainerEvent{
+			Type:      compose.ContainerEventErr,
+			Container: containerName,
+			Service:   serviceName,
+			Line:      line,
+		})
+	})
+	_, err = s.attachContainerStreams(ctx, container.ID, service.Tty, nil, w, errWriter)
 	return err
 }
 
-func (s *composeService) attachContainerStreams(ctx context.Context, container string, tty bool, r io.ReadCloser, w io.Writer) (func(), error) {
+func (s *composeService) attachContainerStreams(ctx context.Context, container string, tty bool, r io.ReadCloser, w io.Writer, errWriter io.Writer) (func(), error) {
 	var (
 		in      *streams.In
 		restore = func() { /* noop */ }
@@ -120,7 +128,7 @@ func (s *composeService) attachContainerStreams(ctx context.Context, container s
 			if tty {
 				io.Copy(w, stdout) // nolint:errcheck
 			} else {
-				stdcopy.StdCopy(w, w, stdout) // nolint:errcheck
+				stdcopy.StdCopy(w, errWriter, stdout) // nolint:errcheck
 			}
 		}()
 	}
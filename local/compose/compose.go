@@ -65,12 +65,19 @@ func getContainerNameWithoutProject(c moby.Container) string {
 }
 
 func (s *composeService) Convert(ctx context.Context, project *types.Project, options compose.ConvertOptions) ([]byte, error) {
+	if options.FromRunning {
+		p, err := s.projectFromRunningContainers(ctx, project.Name, options.Services)
+		if err != nil {
+			return nil, err
+		}
+		project = p
+	}
 	switch options.Format {
 	case "json":
 		return json.MarshalIndent(project, "", "  ")
 	case "yaml":
 		return yaml.Marshal(project)
 	default:
-		return nil, fmt.Errorf("unsupported format %q", options)
+		return nil, fmt.Errorf("unsupported format %q", options.Format)
 	}
 }
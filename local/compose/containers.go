@@ -19,6 +19,7 @@ package compose
 import (
 	"context"
 	"sort"
+	"strings"
 
 	moby "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -81,6 +82,26 @@ func isNotService(services ...string) containerPredicate {
 	}
 }
 
+func hasStatus(statuses ...string) containerPredicate {
+	return func(c moby.Container) bool {
+		return utils.StringContains(statuses, c.State)
+	}
+}
+
+// hasLabels selects containers with all labels, set as `key` or `key=value`
+func hasLabels(labels ...string) containerPredicate {
+	return func(c moby.Container) bool {
+		for _, label := range labels {
+			kv := strings.SplitN(label, "=", 2)
+			value, ok := c.Labels[kv[0]]
+			if !ok || (len(kv) == 2 && value != kv[1]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func isNotOneOff(c moby.Container) bool {
 	v, ok := c.Labels[compose.OneoffLabel]
 	return !ok || v == "False"
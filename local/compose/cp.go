@@ -21,8 +21,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
-	"strings"
 
 	"golang.org/x/sync/errgroup"
 
@@ -34,6 +34,8 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/system"
 	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/utils"
 )
 
 type copyDirection int
@@ -45,102 +47,160 @@ const (
 )
 
 func (s *composeService) Copy(ctx context.Context, project *types.Project, opts compose.CopyOptions) error {
-	srcService, srcPath := splitCpArg(opts.Source)
-	destService, dstPath := splitCpArg(opts.Destination)
+	srcService, srcPath := utils.SplitCopyArg(opts.Source)
+	destService, dstPath := utils.SplitCopyArg(opts.Destination)
 
 	var direction copyDirection
-	var serviceName string
 	if srcService != "" {
 		direction |= fromService
-		serviceName = srcService
-
-		// copying from multiple containers of a services doesn't make sense.
-		if opts.All {
-			return errors.New("cannot use the --all flag when copying from a service")
-		}
 	}
 	if destService != "" {
 		direction |= toService
-		serviceName = destService
 	}
 
+	g := errgroup.Group{}
+	switch direction {
+	case fromService:
+		if opts.All && dstPath == "-" {
+			return errors.New("cannot use the --all flag when copying from a service to stdout")
+		}
+		containers, err := s.getCopyContainers(ctx, project.Name, srcService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range containers {
+			container := containers[i]
+			g.Go(func() error {
+				dst := dstPath
+				if opts.All {
+					// copy from each replica into its own sub-directory
+					dst = filepath.Join(dstPath, getContainerNameWithoutProject(container))
+					if err := os.MkdirAll(dst, 0755); err != nil {
+						return err
+					}
+				}
+				return s.copyFromContainer(ctx, container.ID, srcPath, dst, opts)
+			})
+		}
+	case toService:
+		if opts.All && srcPath == "-" {
+			return errors.New("cannot use the --all flag when copying from stdin to a service")
+		}
+		containers, err := s.getCopyContainers(ctx, project.Name, destService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range containers {
+			containerID := containers[i].ID
+			g.Go(func() error {
+				return s.copyToContainer(ctx, containerID, srcPath, dstPath, opts)
+			})
+		}
+	case acrossServices:
+		sources, err := s.getCopyContainers(ctx, project.Name, srcService, false, opts.Index)
+		if err != nil {
+			return err
+		}
+		containers, err := s.getCopyContainers(ctx, project.Name, destService, opts.All, opts.Index)
+		if err != nil {
+			return err
+		}
+		for i := range containers {
+			containerID := containers[i].ID
+			g.Go(func() error {
+				return s.copyAcrossContainers(ctx, sources[0].ID, srcPath, containerID, dstPath, opts)
+			})
+		}
+	default:
+		return errors.New("unknown copy direction")
+	}
+
+	return g.Wait()
+}
+
+func (s *composeService) getCopyContainers(ctx context.Context, projectName string, serviceName string, all bool, index int) (Containers, error) {
 	f := filters.NewArgs(
-		projectFilter(project.Name),
+		projectFilter(projectName),
 		serviceFilter(serviceName),
 	)
-	if !opts.All {
-		f.Add("label", fmt.Sprintf("%s=%d", compose.ContainerNumberLabel, opts.Index))
+	if !all {
+		f.Add("label", fmt.Sprintf("%s=%d", compose.ContainerNumberLabel, index))
 	}
 	containers, err := s.apiClient.ContainerList(ctx, apitypes.ContainerListOptions{Filters: f})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(containers) < 1 {
-		return fmt.Errorf("service %s not running", serviceName)
+		return nil, fmt.Errorf("service %s not running", serviceName)
 	}
+	return containers, nil
+}
 
-	g := errgroup.Group{}
-	for i := range containers {
-		containerID := containers[i].ID
-
-		g.Go(func() error {
-			switch direction {
-			case fromService:
-				return s.copyFromContainer(ctx, containerID, srcPath, dstPath, opts)
-			case toService:
-				return s.copyToContainer(ctx, containerID, srcPath, dstPath, opts)
-			case acrossServices:
-				return errors.New("copying between services is not supported")
-			default:
-				return errors.New("unknown copy direction")
-			}
-		})
+// copyAcrossContainers streams content from a container to another one, without a temporary copy on local filesystem
+func (s *composeService) copyAcrossContainers(ctx context.Context, srcContainerID, srcPath, dstContainerID, dstPath string, opts compose.CopyOptions) error {
+	dstInfo, err := s.getContainerDestinationInfo(ctx, dstContainerID, dstPath)
+	if err != nil {
+		return err
 	}
 
-	return g.Wait()
-}
+	options := apitypes.CopyToContainerOptions{
+		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                opts.CopyUIDGID,
+	}
 
-func (s *composeService) copyToContainer(ctx context.Context, containerID string, srcPath string, dstPath string, opts compose.CopyOptions) error {
-	var err error
-	if srcPath != "-" {
-		// Get an absolute source path.
-		srcPath, err = resolveLocalPath(srcPath)
+	if utils.HasGlobPattern(srcPath) {
+		if !dstInfo.IsDir {
+			return errors.Errorf("destination \"%s:%s\" must be a directory", dstContainerID, dstPath)
+		}
+		content, err := s.copyGlobFromContainer(ctx, srcContainerID, srcPath)
 		if err != nil {
 			return err
 		}
+		defer content.Close() //nolint:errcheck
+		return s.apiClient.CopyToContainer(ctx, dstContainerID, dstInfo.Path, content, options)
 	}
 
-	// Prepare destination copy info by stat-ing the container path.
-	dstInfo := archive.CopyInfo{Path: dstPath}
-	dstStat, err := s.apiClient.ContainerStatPath(ctx, containerID, dstPath)
+	rebaseName := ""
+	if opts.FollowLink {
+		srcPath, rebaseName = s.followContainerLink(ctx, srcContainerID, srcPath)
+	}
 
-	// If the destination is a symbolic link, we should evaluate it.
-	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
-		linkTarget := dstStat.LinkTarget
-		if !system.IsAbs(linkTarget) {
-			// Join with the parent directory.
-			dstParent, _ := archive.SplitPathDirEntry(dstPath)
-			linkTarget = filepath.Join(dstParent, linkTarget)
-		}
+	content, stat, err := s.apiClient.CopyFromContainer(ctx, srcContainerID, srcPath)
+	if err != nil {
+		return err
+	}
+	defer content.Close() //nolint:errcheck
 
-		dstInfo.Path = linkTarget
-		dstStat, err = s.apiClient.ContainerStatPath(ctx, containerID, linkTarget)
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      stat.Mode.IsDir(),
+		RebaseName: rebaseName,
 	}
 
-	// Validate the destination path
-	if err := command.ValidateOutputPathFileMode(dstStat.Mode); err != nil {
-		return errors.Wrapf(err, `destination "%s:%s" must be a directory or a regular file`, containerID, dstPath)
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(content, srcInfo, dstInfo)
+	if err != nil {
+		return err
 	}
+	defer preparedArchive.Close() //nolint:errcheck
 
-	// Ignore any error and assume that the parent directory of the destination
-	// path exists, in which case the copy may still succeed. If there is any
-	// type of conflict (e.g., non-directory overwriting an existing directory
-	// or vice versa) the extraction will fail. If the destination simply did
-	// not exist, but the parent directory does, the extraction will still
-	// succeed.
-	if err == nil {
-		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	return s.apiClient.CopyToContainer(ctx, dstContainerID, dstDir, preparedArchive, options)
+}
+
+func (s *composeService) copyToContainer(ctx context.Context, containerID string, srcPath string, dstPath string, opts compose.CopyOptions) error {
+	var err error
+	if srcPath != "-" {
+		// Get an absolute source path.
+		srcPath, err = utils.ResolveLocalPath(srcPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	dstInfo, err := s.getContainerDestinationInfo(ctx, containerID, dstPath)
+	if err != nil {
+		return err
 	}
 
 	var (
@@ -200,7 +260,7 @@ func (s *composeService) copyFromContainer(ctx context.Context, containerID, src
 	var err error
 	if dstPath != "-" {
 		// Get an absolute destination path.
-		dstPath, err = resolveLocalPath(dstPath)
+		dstPath, err = utils.ResolveLocalPath(dstPath)
 		if err != nil {
 			return err
 		}
@@ -210,23 +270,27 @@ func (s *composeService) copyFromContainer(ctx context.Context, containerID, src
 		return err
 	}
 
+	if utils.HasGlobPattern(srcPath) {
+		content, err := s.copyGlobFromContainer(ctx, containerID, srcPath)
+		if err != nil {
+			return err
+		}
+		defer content.Close() //nolint:errcheck
+
+		if dstPath == "-" {
+			_, err = io.Copy(os.Stdout, content)
+			return err
+		}
+		if err := os.MkdirAll(dstPath, 0755); err != nil {
+			return err
+		}
+		return archive.Untar(content, dstPath, &archive.TarOptions{NoLchown: true})
+	}
+
 	// if client requests to follow symbol link, then must decide target file to be copied
 	var rebaseName string
 	if opts.FollowLink {
-		srcStat, err := s.apiClient.ContainerStatPath(ctx, containerID, srcPath)
-
-		// If the destination is a symbolic link, we should follow it.
-		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
-			linkTarget := srcStat.LinkTarget
-			if !system.IsAbs(linkTarget) {
-				// Join with the parent directory.
-				srcParent, _ := archive.SplitPathDirEntry(srcPath)
-				linkTarget = filepath.Join(srcParent, linkTarget)
-			}
-
-			linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
-			srcPath = linkTarget
-		}
+		srcPath, rebaseName = s.followContainerLink(ctx, containerID, srcPath)
 	}
 
 	content, stat, err := s.apiClient.CopyFromContainer(ctx, containerID, srcPath)
@@ -256,26 +320,80 @@ func (s *composeService) copyFromContainer(ctx context.Context, containerID, src
 	return archive.CopyTo(preArchive, srcInfo, dstPath)
 }
 
-func splitCpArg(arg string) (container, path string) {
-	if system.IsAbs(arg) {
-		// Explicit local absolute path, e.g., `C:\foo` or `/foo`.
-		return "", arg
+// getContainerDestinationInfo prepares destination copy info by stat-ing the container path
+func (s *composeService) getContainerDestinationInfo(ctx context.Context, containerID string, dstPath string) (archive.CopyInfo, error) {
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	dstStat, err := s.apiClient.ContainerStatPath(ctx, containerID, dstPath)
+
+	// If the destination is a symbolic link, we should evaluate it.
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+
+		dstInfo.Path = linkTarget
+		dstStat, err = s.apiClient.ContainerStatPath(ctx, containerID, linkTarget)
 	}
 
-	parts := strings.SplitN(arg, ":", 2)
+	// Validate the destination path
+	if err := command.ValidateOutputPathFileMode(dstStat.Mode); err != nil {
+		return dstInfo, errors.Wrapf(err, `destination "%s:%s" must be a directory or a regular file`, containerID, dstPath)
+	}
 
-	if len(parts) == 1 || strings.HasPrefix(parts[0], ".") {
-		// Either there's no `:` in the arg
-		// OR it's an explicit local relative path like `./file:name.txt`.
-		return "", arg
+	// Ignore any error and assume that the parent directory of the destination
+	// path exists, in which case the copy may still succeed. If there is any
+	// type of conflict (e.g., non-directory overwriting an existing directory
+	// or vice versa) the extraction will fail. If the destination simply did
+	// not exist, but the parent directory does, the extraction will still
+	// succeed.
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
 	}
+	return dstInfo, nil
+}
 
-	return parts[0], parts[1]
+func (s *composeService) followContainerLink(ctx context.Context, containerID string, srcPath string) (string, string) {
+	var rebaseName string
+	srcStat, err := s.apiClient.ContainerStatPath(ctx, containerID, srcPath)
+
+	// If the source is a symbolic link, we should follow it.
+	if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := srcStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			srcParent, _ := archive.SplitPathDirEntry(srcPath)
+			linkTarget = filepath.Join(srcParent, linkTarget)
+		}
+
+		linkTarget, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+		srcPath = linkTarget
+	}
+	return srcPath, rebaseName
 }
 
-func resolveLocalPath(localPath string) (absPath string, err error) {
-	if absPath, err = filepath.Abs(localPath); err != nil {
-		return
+// copyGlobFromContainer returns a tar archive with the files from container matching srcPath glob pattern
+func (s *composeService) copyGlobFromContainer(ctx context.Context, containerID string, srcPath string) (io.ReadCloser, error) {
+	dir, pattern := path.Split(srcPath)
+	if utils.HasGlobPattern(dir) {
+		return nil, errors.Errorf("glob pattern is only supported on the last element of path %q", srcPath)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	content, stat, err := s.apiClient.CopyFromContainer(ctx, containerID, dir)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode.IsDir() {
+		content.Close() //nolint:errcheck
+		return nil, errors.Errorf("%q is not a directory", dir)
 	}
-	return archive.PreserveTrailingDotOrSeparator(absPath, localPath, filepath.Separator), nil
+	return utils.FilterArchive(content, stat.Name, pattern), nil
 }
@@ -0,0 +1,110 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestCopyAcrossServices(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	project := types.Project{Name: testProject, Services: []types.ServiceConfig{testService("db"), testService("restore")}}
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, copyListOpt("db", 1)).Return([]apitypes.Container{testContainer("db", "123")}, nil)
+	api.EXPECT().ContainerList(ctx, copyListOpt("restore", 1)).Return([]apitypes.Container{testContainer("restore", "456")}, nil)
+	api.EXPECT().ContainerStatPath(ctx, "456", "/import/").Return(apitypes.ContainerPathStat{Name: "import", Mode: os.ModeDir | 0755}, nil)
+	api.EXPECT().CopyFromContainer(ctx, "123", "/backup/dump.sql").
+		Return(testArchive(t, "dump.sql"), apitypes.ContainerPathStat{Name: "dump.sql", Mode: 0644}, nil)
+	api.EXPECT().CopyToContainer(ctx, "456", "/import/", gomock.Any(), apitypes.CopyToContainerOptions{}).
+		DoAndReturn(func(ctx context.Context, container, path string, content io.Reader, options apitypes.CopyToContainerOptions) error {
+			assert.DeepEqual(t, archiveEntries(t, content), []string{"dump.sql"})
+			return nil
+		})
+
+	err := tested.Copy(ctx, &project, compose.CopyOptions{
+		Source:      "db:/backup/dump.sql",
+		Destination: "restore:/import/",
+		Index:       1,
+	})
+	assert.NilError(t, err)
+}
+
+func TestCopyAllFromStdin(t *testing.T) {
+	project := types.Project{Name: testProject, Services: []types.ServiceConfig{testService("db")}}
+
+	err := tested.Copy(context.Background(), &project, compose.CopyOptions{
+		Source:      "-",
+		Destination: "db:/import/",
+		All:         true,
+	})
+	assert.ErrorContains(t, err, "cannot use the --all flag when copying from stdin to a service")
+}
+
+func copyListOpt(service string, index int) apitypes.ContainerListOptions {
+	f := filters.NewArgs(projectFilter(testProject), serviceFilter(service))
+	f.Add("label", fmt.Sprintf("%s=%d", compose.ContainerNumberLabel, index))
+	return apitypes.ContainerListOptions{Filters: f}
+}
+
+func testArchive(t *testing.T, names ...string) io.ReadCloser {
+	buf := bytes.Buffer{}
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		hdr := &tar.Header{Name: name, Mode: 0644, Typeflag: tar.TypeReg}
+		if name[len(name)-1] == '/' {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0755
+		}
+		assert.NilError(t, tw.WriteHeader(hdr))
+	}
+	assert.NilError(t, tw.Close())
+	return ioutil.NopCloser(&buf)
+}
+
+func archiveEntries(t *testing.T, content io.Reader) []string {
+	var entries []string
+	tr := tar.NewReader(content)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		assert.NilError(t, err)
+		entries = append(entries, hdr.Name)
+	}
+}
@@ -64,7 +64,7 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 	containerState := NewContainersState(observedState)
 	ctx = context.WithValue(ctx, ContainersKey{}, containerState)
 
-	err = s.ensureImagesExists(ctx, project, observedState, options.QuietPull)
+	err = s.ensureImagesExists(ctx, project, observedState, options.QuietPull, options.PullParallelism)
 	if err != nil {
 		return err
 	}
@@ -76,7 +76,7 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 		return err
 	}
 
-	if err := s.ensureNetworks(ctx, project.Networks); err != nil {
+	if err := s.ensureNetworks(ctx, project.Networks, options.RecreateNetworks); err != nil {
 		return err
 	}
 
@@ -107,6 +107,10 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 
 	prepareServicesDependsOn(project)
 
+	for _, service := range project.Services {
+		warnUnsupportedServiceOptions(service)
+	}
+
 	return InDependencyOrder(ctx, project, func(c context.Context, service types.ServiceConfig) error {
 		if utils.StringContains(options.Services, service.Name) {
 			return s.ensureService(c, project, service, options.Recreate, options.Inherit, options.Timeout)
@@ -171,9 +175,9 @@ outLoop:
 	}
 }
 
-func (s *composeService) ensureNetworks(ctx context.Context, networks types.Networks) error {
+func (s *composeService) ensureNetworks(ctx context.Context, networks types.Networks, recreate bool) error {
 	for _, network := range networks {
-		err := s.ensureNetwork(ctx, network)
+		err := s.ensureNetwork(ctx, network, recreate)
 		if err != nil {
 			return err
 		}
@@ -304,6 +308,11 @@ func (s *composeService) getCreateOptions(ctx context.Context, p *types.Project,
 		return nil, nil, nil, err
 	}
 
+	links, err := s.getLinks(ctx, p.Name, service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	tmpfs := map[string]string{}
 	for _, t := range service.Tmpfs {
 		if arr := strings.SplitN(t, ":", 2); len(arr) > 1 {
@@ -342,18 +351,68 @@ func (s *composeService) getCreateOptions(ctx context.Context, p *types.Project,
 		DNSSearch:      service.DNSSearch,
 		DNSOptions:     service.DNSOpts,
 		ExtraHosts:     service.ExtraHosts,
-		SecurityOpt:    service.SecurityOpt,
+		SecurityOpt:    getSecurityOpts(service),
 		UsernsMode:     container.UsernsMode(service.UserNSMode),
+		UTSMode:        container.UTSMode(service.Uts),
 		Privileged:     service.Privileged,
 		PidMode:        container.PidMode(service.Pid),
 		Tmpfs:          tmpfs,
 		Isolation:      container.Isolation(service.Isolation),
 		LogConfig:      logConfig,
+		GroupAdd:       service.GroupAdd,
+		Links:          links,
+		OomScoreAdj:    int(service.OomScoreAdj),
+		Runtime:        service.Runtime,
 	}
 
 	return &containerConfig, &hostConfig, networkConfig, nil
 }
 
+// getLinks resolves service links into links to the linked services containers, using service name or alias and
+// container name as link aliases
+func (s *composeService) getLinks(ctx context.Context, projectName string, service types.ServiceConfig) ([]string, error) {
+	var links []string
+	for _, link := range service.Links {
+		linkSplit := strings.SplitN(link, ":", 2)
+		linkService := linkSplit[0]
+		linkAlias := linkService
+		if len(linkSplit) == 2 {
+			linkAlias = linkSplit[1]
+		}
+		containers, err := s.getContainers(ctx, projectName, oneOffExclude, true, linkService)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range containers {
+			name := getCanonicalContainerName(c)
+			links = append(links, fmt.Sprintf("%s:%s", name, linkAlias), fmt.Sprintf("%s:%s", name, name))
+		}
+	}
+	for _, link := range service.ExternalLinks {
+		linkSplit := strings.SplitN(link, ":", 2)
+		if len(linkSplit) == 1 {
+			link = fmt.Sprintf("%s:%s", link, link)
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// getSecurityOpts adds Windows credential spec to service security options
+func getSecurityOpts(service types.ServiceConfig) []string {
+	var securityOpts []string
+	securityOpts = append(securityOpts, service.SecurityOpt...)
+	if spec := service.CredentialSpec; spec != nil {
+		switch {
+		case spec.File != "":
+			securityOpts = append(securityOpts, "credentialspec=file://"+spec.File)
+		case spec.Registry != "":
+			securityOpts = append(securityOpts, "credentialspec=registry://"+spec.Registry)
+		}
+	}
+	return securityOpts
+}
+
 func getDefaultNetworkMode(project *types.Project, service types.ServiceConfig) string {
 	mode := "none"
 	if len(project.Networks) > 0 {
@@ -384,8 +443,15 @@ func getRestartPolicy(service types.ServiceConfig) container.RestartPolicy {
 		if policy.MaxAttempts != nil {
 			attempts = int(*policy.MaxAttempts)
 		}
+		name := policy.Condition
+		switch name {
+		case "any":
+			name = "always"
+		case "none":
+			name = "no"
+		}
 		restart = container.RestartPolicy{
-			Name:              policy.Condition,
+			Name:              name,
 			MaximumRetryCount: attempts,
 		}
 	}
@@ -398,19 +464,30 @@ func getDeployResources(s types.ServiceConfig) container.Resources {
 		val := int64(s.MemSwappiness)
 		swappiness = &val
 	}
+	var pidsLimit *int64
+	if s.PidsLimit != 0 {
+		pidsLimit = &s.PidsLimit
+	}
+	var oomKillDisable *bool
+	if s.OomKillDisable {
+		oomKillDisable = &s.OomKillDisable
+	}
 	resources := container.Resources{
 		CgroupParent:       s.CgroupParent,
 		Memory:             int64(s.MemLimit),
 		MemorySwap:         int64(s.MemSwapLimit),
 		MemorySwappiness:   swappiness,
 		MemoryReservation:  int64(s.MemReservation),
+		OomKillDisable:     oomKillDisable,
+		PidsLimit:          pidsLimit,
 		CPUCount:           s.CPUCount,
 		CPUPeriod:          s.CPUPeriod,
 		CPUQuota:           s.CPUQuota,
 		CPURealtimePeriod:  s.CPURTPeriod,
 		CPURealtimeRuntime: s.CPURTRuntime,
 		CPUShares:          s.CPUShares,
-		CPUPercent:         int64(s.CPUS * 100),
+		CPUPercent:         int64(s.CPUPercent),
+		NanoCPUs:           int64(s.CPUS * 1e9),
 		CpusetCpus:         s.CPUSet,
 	}
 
@@ -466,6 +543,9 @@ func setReservations(reservations *types.Resource, resources *container.Resource
 	if reservations == nil {
 		return
 	}
+	if reservations.MemoryBytes != 0 {
+		resources.MemoryReservation = int64(reservations.MemoryBytes)
+	}
 	for _, device := range reservations.Devices {
 		resources.DeviceRequests = append(resources.DeviceRequests, container.DeviceRequest{
 			Capabilities: [][]string{device.Capabilities},
@@ -484,8 +564,9 @@ func setLimits(limits *types.Resource, resources *container.Resources) {
 		resources.Memory = int64(limits.MemoryBytes)
 	}
 	if limits.NanoCPUs != "" {
-		i, _ := strconv.ParseInt(limits.NanoCPUs, 10, 64)
-		resources.NanoCPUs = i
+		// deploy.resources.limits.cpus is a number of CPUs, i.e. "0.5"
+		cpus, _ := strconv.ParseFloat(limits.NanoCPUs, 64)
+		resources.NanoCPUs = int64(cpus * 1e9)
 	}
 }
 
@@ -528,6 +609,16 @@ func setBlkio(blkio *types.BlkioConfig, resources *container.Resources) {
 
 func buildContainerPorts(s types.ServiceConfig) nat.PortSet {
 	ports := nat.PortSet{}
+	for _, p := range s.Expose {
+		proto, port := nat.SplitProtoPort(p)
+		start, end, err := nat.ParsePortRange(port)
+		if err != nil {
+			continue
+		}
+		for i := start; i <= end; i++ {
+			ports[nat.Port(fmt.Sprintf("%d/%s", i, proto))] = struct{}{}
+		}
+	}
 	for _, p := range s.Ports {
 		p := nat.Port(fmt.Sprintf("%d/%s", p.Target, p.Protocol))
 		ports[p] = struct{}{}
@@ -910,48 +1001,64 @@ func getNetworksForService(s types.ServiceConfig) map[string]*types.ServiceNetwo
 	return map[string]*types.ServiceNetworkConfig{"default": nil}
 }
 
-func (s *composeService) ensureNetwork(ctx context.Context, n types.NetworkConfig) error {
-	_, err := s.apiClient.NetworkInspect(ctx, n.Name, moby.NetworkInspectOptions{})
+func (s *composeService) ensureNetwork(ctx context.Context, n types.NetworkConfig, recreate bool) error {
+	inspect, err := s.apiClient.NetworkInspect(ctx, n.Name, moby.NetworkInspectOptions{})
 	if err != nil {
 		if errdefs.IsNotFound(err) {
 			if n.External.External {
 				return fmt.Errorf("network %s declared as external, but could not be found", n.Name)
 			}
-			createOpts := moby.NetworkCreate{
-				// TODO NameSpace Labels
-				Labels:     n.Labels,
-				Driver:     n.Driver,
-				Options:    n.DriverOpts,
-				Internal:   n.Internal,
-				Attachable: n.Attachable,
-			}
+			return s.createNetwork(ctx, n)
+		}
+		return err
+	}
+	if n.External.External {
+		return nil
+	}
+	drift := getNetworkDrift(n, inspect)
+	if len(drift) == 0 {
+		return nil
+	}
+	if !recreate {
+		logrus.Warnf("Network %s exists but doesn't match configuration (%s). "+
+			"Run with --recreate-networks to recreate it.", n.Name, strings.Join(drift, ", "))
+		return nil
+	}
+	return s.recreateNetwork(ctx, n, inspect)
+}
 
-			if n.Ipam.Driver != "" || len(n.Ipam.Config) > 0 {
-				createOpts.IPAM = &network.IPAM{}
-			}
+func (s *composeService) createNetwork(ctx context.Context, n types.NetworkConfig) error {
+	createOpts := moby.NetworkCreate{
+		// TODO NameSpace Labels
+		Labels:     n.Labels,
+		Driver:     n.Driver,
+		Options:    n.DriverOpts,
+		Internal:   n.Internal,
+		Attachable: n.Attachable,
+	}
 
-			if n.Ipam.Driver != "" {
-				createOpts.IPAM.Driver = n.Ipam.Driver
-			}
+	if n.Ipam.Driver != "" || len(n.Ipam.Config) > 0 {
+		createOpts.IPAM = &network.IPAM{}
+	}
 
-			for _, ipamConfig := range n.Ipam.Config {
-				config := network.IPAMConfig{
-					Subnet: ipamConfig.Subnet,
-				}
-				createOpts.IPAM.Config = append(createOpts.IPAM.Config, config)
-			}
-			networkEventName := fmt.Sprintf("Network %s", n.Name)
-			w := progress.ContextWriter(ctx)
-			w.Event(progress.CreatingEvent(networkEventName))
-			if _, err := s.apiClient.NetworkCreate(ctx, n.Name, createOpts); err != nil {
-				w.Event(progress.ErrorEvent(networkEventName))
-				return errors.Wrapf(err, "failed to create network %s", n.Name)
-			}
-			w.Event(progress.CreatedEvent(networkEventName))
-			return nil
+	if n.Ipam.Driver != "" {
+		createOpts.IPAM.Driver = n.Ipam.Driver
+	}
+
+	for _, ipamConfig := range n.Ipam.Config {
+		config := network.IPAMConfig{
+			Subnet: ipamConfig.Subnet,
 		}
-		return err
+		createOpts.IPAM.Config = append(createOpts.IPAM.Config, config)
+	}
+	networkEventName := fmt.Sprintf("Network %s", n.Name)
+	w := progress.ContextWriter(ctx)
+	w.Event(progress.CreatingEvent(networkEventName))
+	if _, err := s.apiClient.NetworkCreate(ctx, n.Name, createOpts); err != nil {
+		w.Event(progress.ErrorEvent(networkEventName))
+		return errors.Wrapf(err, "failed to create network %s", n.Name)
 	}
+	w.Event(progress.CreatedEvent(networkEventName))
 	return nil
 }
 
@@ -971,7 +1078,14 @@ func (s *composeService) removeNetwork(ctx context.Context, networkID string, ne
 
 func (s *composeService) ensureVolume(ctx context.Context, volume types.VolumeConfig) error {
 	// TODO could identify volume by label vs name
-	_, err := s.apiClient.VolumeInspect(ctx, volume.Name)
+	inspect, err := s.apiClient.VolumeInspect(ctx, volume.Name)
+	if err == nil && !volume.External.External {
+		// volumes hold data, so we never recreate them but let user know about changes not applied
+		if drift := getVolumeDrift(volume, inspect); len(drift) > 0 {
+			logrus.Warnf("Volume %q exists but doesn't match configuration (%s). "+
+				"It will be used as is, remove it to apply the new configuration.", volume.Name, strings.Join(drift, ", "))
+		}
+	}
 	if err != nil {
 		if !errdefs.IsNotFound(err) {
 			return err
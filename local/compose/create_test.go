@@ -26,6 +26,7 @@ import (
 	"github.com/compose-spec/compose-go/types"
 	composetypes "github.com/compose-spec/compose-go/types"
 	mountTypes "github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
 	"gotest.tools/v3/assert"
 )
 
@@ -81,3 +82,53 @@ func TestPrepareNetworkLabels(t *testing.T) {
 		"com.docker.compose.version": internal.Version,
 	}))
 }
+
+func TestGetDeployResources(t *testing.T) {
+	replicas := uint64(1)
+	resources := getDeployResources(types.ServiceConfig{
+		CPUS:           1.5,
+		CPUPercent:     50,
+		PidsLimit:      100,
+		OomKillDisable: true,
+		Deploy: &types.DeployConfig{
+			Replicas: &replicas,
+			Resources: types.Resources{
+				Limits:       &types.Resource{NanoCPUs: "0.5"},
+				Reservations: &types.Resource{MemoryBytes: 1024},
+			},
+		},
+	})
+	assert.Equal(t, resources.NanoCPUs, int64(500000000))
+	assert.Equal(t, resources.CPUPercent, int64(50))
+	assert.Equal(t, *resources.PidsLimit, int64(100))
+	assert.Equal(t, *resources.OomKillDisable, true)
+	assert.Equal(t, resources.MemoryReservation, int64(1024))
+}
+
+func TestBuildContainerPortsWithExpose(t *testing.T) {
+	ports := buildContainerPorts(types.ServiceConfig{
+		Expose: types.StringOrNumberList{"3000", "4000-4001/udp"},
+		Ports:  []types.ServicePortConfig{{Target: 80, Protocol: "tcp"}},
+	})
+	assert.DeepEqual(t, ports, nat.PortSet{
+		"3000/tcp": {},
+		"4000/udp": {},
+		"4001/udp": {},
+		"80/tcp":   {},
+	})
+}
+
+func TestGetSecurityOpts(t *testing.T) {
+	opts := getSecurityOpts(types.ServiceConfig{
+		SecurityOpt:    []string{"no-new-privileges"},
+		CredentialSpec: &types.CredentialSpecConfig{File: "spec.json"},
+	})
+	assert.DeepEqual(t, opts, []string{"no-new-privileges", "credentialspec=file://spec.json"})
+}
+
+func TestGetRestartPolicyFromDeploy(t *testing.T) {
+	policy := getRestartPolicy(types.ServiceConfig{
+		Deploy: &types.DeployConfig{RestartPolicy: &types.RestartPolicy{Condition: "any"}},
+	})
+	assert.Equal(t, policy.Name, "always")
+}
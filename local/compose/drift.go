@@ -0,0 +1,139 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/progress"
+)
+
+// getNetworkDrift lists differences between network configuration from model and existing network
+func getNetworkDrift(n types.NetworkConfig, inspect moby.NetworkResource) []string {
+	var drift []string
+	if n.Driver != "" && n.Driver != inspect.Driver {
+		drift = append(drift, fmt.Sprintf("driver is %q, expected %q", inspect.Driver, n.Driver))
+	}
+	drift = append(drift, getOptionsDrift("driver option", n.DriverOpts, inspect.Options)...)
+	if n.Internal != inspect.Internal {
+		drift = append(drift, fmt.Sprintf("internal is %t, expected %t", inspect.Internal, n.Internal))
+	}
+	if n.Attachable != inspect.Attachable {
+		drift = append(drift, fmt.Sprintf("attachable is %t, expected %t", inspect.Attachable, n.Attachable))
+	}
+	if n.Ipam.Driver != "" && n.Ipam.Driver != inspect.IPAM.Driver {
+		drift = append(drift, fmt.Sprintf("IPAM driver is %q, expected %q", inspect.IPAM.Driver, n.Ipam.Driver))
+	}
+	if len(n.Ipam.Config) > 0 {
+		var expected, actual []string
+		for _, config := range n.Ipam.Config {
+			expected = append(expected, config.Subnet)
+		}
+		for _, config := range inspect.IPAM.Config {
+			actual = append(actual, config.Subnet)
+		}
+		sort.Strings(expected)
+		sort.Strings(actual)
+		if !stringSliceEqual(expected, actual) {
+			drift = append(drift, fmt.Sprintf("subnets are [%s], expected [%s]", strings.Join(actual, " "), strings.Join(expected, " ")))
+		}
+	}
+	drift = append(drift, getOptionsDrift("label", withoutVersionLabel(n.Labels), inspect.Labels)...)
+	return drift
+}
+
+// getVolumeDrift lists differences between volume configuration from model and existing volume
+func getVolumeDrift(v types.VolumeConfig, inspect moby.Volume) []string {
+	var drift []string
+	if v.Driver != "" && v.Driver != inspect.Driver {
+		drift = append(drift, fmt.Sprintf("driver is %q, expected %q", inspect.Driver, v.Driver))
+	}
+	drift = append(drift, getOptionsDrift("driver option", v.DriverOpts, inspect.Options)...)
+	drift = append(drift, getOptionsDrift("label", withoutVersionLabel(v.Labels), inspect.Labels)...)
+	return drift
+}
+
+func getOptionsDrift(kind string, expected map[string]string, actual map[string]string) []string {
+	var drift []string
+	for k, v := range expected {
+		if actual[k] != v {
+			drift = append(drift, fmt.Sprintf("%s %s is %q, expected %q", kind, k, actual[k], v))
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// withoutVersionLabel ignores compose version, which isn't a configuration change
+func withoutVersionLabel(labels types.Labels) map[string]string {
+	l := map[string]string{}
+	for k, v := range labels {
+		if k != compose.VersionLabel {
+			l[k] = v
+		}
+	}
+	return l
+}
+
+// recreateNetwork detaches containers from existing network, recreates it according to model, then reattaches containers
+func (s *composeService) recreateNetwork(ctx context.Context, n types.NetworkConfig, inspect moby.NetworkResource) error {
+	w := progress.ContextWriter(ctx)
+	eventName := fmt.Sprintf("Network %s", n.Name)
+	w.Event(progress.NewEvent(eventName, progress.Working, "Recreate"))
+
+	endpoints := map[string]*network.EndpointSettings{}
+	for id := range inspect.Containers {
+		container, err := s.apiClient.ContainerInspect(ctx, id)
+		if err != nil {
+			return err
+		}
+		endpoint := &network.EndpointSettings{}
+		if settings, ok := container.NetworkSettings.Networks[n.Name]; ok {
+			endpoint.Aliases = settings.Aliases
+			endpoint.IPAMConfig = settings.IPAMConfig
+			endpoint.Links = settings.Links
+		}
+		endpoints[id] = endpoint
+		if err := s.apiClient.NetworkDisconnect(ctx, inspect.ID, id, true); err != nil {
+			return err
+		}
+	}
+
+	if err := s.apiClient.NetworkRemove(ctx, inspect.ID); err != nil {
+		w.Event(progress.ErrorEvent(eventName))
+		return err
+	}
+	if err := s.createNetwork(ctx, n); err != nil {
+		return err
+	}
+
+	for id, endpoint := range endpoints {
+		if err := s.apiClient.NetworkConnect(ctx, n.Name, id, endpoint); err != nil {
+			return err
+		}
+	}
+	w.Event(progress.NewEvent(eventName, progress.Done, "Recreated"))
+	return nil
+}
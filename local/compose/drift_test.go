@@ -0,0 +1,99 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestNetworkDrift(t *testing.T) {
+	n := types.NetworkConfig{
+		Name:   "testProject_default",
+		Driver: "bridge",
+		Ipam: types.IPAMConfig{
+			Config: []*types.IPAMPool{{Subnet: "172.28.0.0/16"}},
+		},
+		Labels: types.Labels{compose.NetworkLabel: "default", compose.VersionLabel: "2.0.0"},
+	}
+	inspect := apitypes.NetworkResource{
+		Driver: "bridge",
+		IPAM:   network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.28.0.0/16"}}},
+		Labels: map[string]string{compose.NetworkLabel: "default", compose.VersionLabel: "1.0.0"},
+	}
+	assert.Equal(t, len(getNetworkDrift(n, inspect)), 0)
+
+	inspect.Driver = "overlay"
+	inspect.Internal = true
+	inspect.IPAM.Config = []network.IPAMConfig{{Subnet: "10.0.0.0/24"}}
+	inspect.Labels = map[string]string{}
+	assert.DeepEqual(t, getNetworkDrift(n, inspect), []string{
+		`driver is "overlay", expected "bridge"`,
+		`internal is true, expected false`,
+		`subnets are [10.0.0.0/24], expected [172.28.0.0/16]`,
+		`label com.docker.compose.network is "", expected "default"`,
+	})
+}
+
+func TestVolumeDrift(t *testing.T) {
+	v := types.VolumeConfig{
+		Name:       "testProject_data",
+		DriverOpts: map[string]string{"type": "nfs"},
+	}
+	assert.Equal(t, len(getVolumeDrift(v, apitypes.Volume{Driver: "local", Options: map[string]string{"type": "nfs"}})), 0)
+	assert.DeepEqual(t, getVolumeDrift(v, apitypes.Volume{Driver: "local"}), []string{`driver option type is "", expected "nfs"`})
+}
+
+func TestRecreateNetwork(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	n := types.NetworkConfig{Name: "testProject_default", Internal: true}
+	api.EXPECT().NetworkInspect(ctx, "testProject_default", apitypes.NetworkInspectOptions{}).Return(apitypes.NetworkResource{
+		ID:     "net",
+		Driver: "bridge",
+		Containers: map[string]apitypes.EndpointResource{
+			"123": {Name: "testProject_web_1"},
+		},
+	}, nil)
+	api.EXPECT().ContainerInspect(ctx, "123").Return(apitypes.ContainerJSON{
+		NetworkSettings: &apitypes.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"testProject_default": {Aliases: []string{"web"}},
+			},
+		},
+	}, nil)
+	api.EXPECT().NetworkDisconnect(ctx, "net", "123", true).Return(nil)
+	api.EXPECT().NetworkRemove(ctx, "net").Return(nil)
+	api.EXPECT().NetworkCreate(ctx, "testProject_default", apitypes.NetworkCreate{Internal: true}).Return(apitypes.NetworkCreateResponse{}, nil)
+	api.EXPECT().NetworkConnect(ctx, "testProject_default", "123", &network.EndpointSettings{Aliases: []string{"web"}}).Return(nil)
+
+	err := tested.ensureNetwork(ctx, n, true)
+	assert.NilError(t, err)
+}
@@ -18,6 +18,8 @@ package compose
 
 import (
 	"context"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,9 +31,21 @@ import (
 )
 
 func (s *composeService) Events(ctx context.Context, project string, options compose.EventsOptions) error {
+	filter := filters.NewArgs(projectFilter(project), filters.Arg("type", "container"))
+	for _, t := range options.Types {
+		filter.Add("event", t)
+		if t == compose.EventHealthStatus {
+			// required to track health state across container restarts
+			filter.Add("event", "start")
+			filter.Add("event", "destroy")
+		}
+	}
 	events, errors := s.apiClient.Events(ctx, moby.EventsOptions{
-		Filters: filters.NewArgs(projectFilter(project)),
+		Filters: filter,
+		Since:   options.Since,
+		Until:   options.Until,
 	})
+	health := map[string]string{}
 	for {
 		select {
 		case event := <-events:
@@ -57,19 +71,73 @@ func (s *composeService) Events(ctx context.Context, project string, options com
 			if event.TimeNano != 0 {
 				timestamp = time.Unix(0, event.TimeNano)
 			}
-			err := options.Consumer(compose.Event{
+			e := compose.Event{
 				Timestamp:  timestamp,
 				Service:    service,
 				Container:  event.ID,
 				Status:     event.Status,
 				Attributes: attributes,
-			})
-			if err != nil {
-				return err
+			}
+			if e.Status == compose.EventDie {
+				e.ExitCode, _ = strconv.Atoi(attributes["exitCode"])
+			}
+			for _, ev := range withHealthTransitions(e, health) {
+				if len(options.Types) > 0 && !utils.StringContains(options.Types, ev.Status) {
+					continue
+				}
+				err := options.Consumer(ev)
+				if err != nil {
+					return err
+				}
 			}
 
 		case err := <-errors:
+			if err == io.EOF {
+				// events stream ends when `until` is reached
+				return nil
+			}
 			return err
 		}
 	}
 }
+
+// withHealthTransitions turns engine `health_status: xx` events into compose health_status events, with the
+// previous container health state set as attribute. As engine doesn't send an event when healthcheck is reset by
+// a container restart, a synthetic health_status event is added after `start`. Events which don't change
+// container health are dropped.
+func withHealthTransitions(event compose.Event, health map[string]string) []compose.Event {
+	transition := func(status string) []compose.Event {
+		previous, known := health[event.Container]
+		if known && previous == status {
+			return nil
+		}
+		health[event.Container] = status
+		attributes := map[string]string{}
+		for k, v := range event.Attributes {
+			attributes[k] = v
+		}
+		attributes[compose.EventHealthStatus] = status
+		if known {
+			attributes["previous_"+compose.EventHealthStatus] = previous
+		}
+		return []compose.Event{{
+			Timestamp:  event.Timestamp,
+			Service:    event.Service,
+			Container:  event.Container,
+			Status:     compose.EventHealthStatus,
+			Attributes: attributes,
+		}}
+	}
+
+	switch {
+	case strings.HasPrefix(event.Status, compose.EventHealthStatus+":"):
+		return transition(strings.TrimSpace(strings.TrimPrefix(event.Status, compose.EventHealthStatus+":")))
+	case event.Status == "start":
+		if _, known := health[event.Container]; known {
+			return append([]compose.Event{event}, transition("starting")...)
+		}
+	case event.Status == "destroy":
+		delete(health, event.Container)
+	}
+	return []compose.Event{event}
+}
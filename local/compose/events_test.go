@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	messages := make(chan events.Message)
+	errs := make(chan error)
+	ctx := context.Background()
+	api.EXPECT().Events(ctx, gomock.Any()).Return(messages, errs)
+
+	event := func(id string, status string, attributes map[string]string) events.Message {
+		attributes[compose.ServiceLabel] = "service1"
+		return events.Message{
+			Type:   "container",
+			ID:     id,
+			Status: status,
+			Actor:  events.Actor{ID: id, Attributes: attributes},
+		}
+	}
+	go func() {
+		messages <- event("123", "health_status: healthy", map[string]string{})
+		messages <- event("123", "health_status: healthy", map[string]string{})
+		messages <- event("123", "die", map[string]string{"exitCode": "137"})
+		messages <- event("123", "oom", map[string]string{})
+		messages <- event("123", "start", map[string]string{})
+		messages <- event("123", "health_status: unhealthy", map[string]string{})
+		errs <- io.EOF
+	}()
+
+	var received []compose.Event
+	err := tested.Events(ctx, testProject, compose.EventsOptions{
+		Types: []string{compose.EventDie, compose.EventHealthStatus},
+		Consumer: func(event compose.Event) error {
+			received = append(received, event)
+			return nil
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(received), 4)
+	assert.Equal(t, received[0].Status, compose.EventHealthStatus)
+	assert.Equal(t, received[0].Attributes[compose.EventHealthStatus], "healthy")
+	assert.Equal(t, received[1].Status, compose.EventDie)
+	assert.Equal(t, received[1].ExitCode, 137)
+	assert.Equal(t, received[2].Attributes[compose.EventHealthStatus], "starting")
+	assert.Equal(t, received[2].Attributes["previous_health_status"], "healthy")
+	assert.Equal(t, received[3].Attributes[compose.EventHealthStatus], "unhealthy")
+	assert.Equal(t, received[3].Attributes["previous_health_status"], "starting")
+}
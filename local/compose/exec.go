@@ -20,16 +20,22 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/pkg/stdcopy"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
 )
 
 func (s *composeService) Exec(ctx context.Context, project *types.Project, opts compose.RunOptions) (int, error) {
+	if opts.All {
+		return s.execAll(ctx, project, opts)
+	}
 	service, err := project.GetService(opts.Service)
 	if err != nil {
 		return 0, err
@@ -87,6 +93,106 @@ func (s *composeService) Exec(ctx context.Context, project *types.Project, opts
 	return s.getExecExitStatus(ctx, exec.ID)
 }
 
+// execAll runs command in every replica of selected services concurrently, and returns the highest exit code
+func (s *composeService) execAll(ctx context.Context, project *types.Project, opts compose.RunOptions) (int, error) {
+	services := opts.Services
+	if len(services) == 0 {
+		services = []string{opts.Service}
+	}
+	for _, name := range services {
+		if _, err := project.GetService(name); err != nil {
+			return 0, err
+		}
+	}
+	containers, err := s.getContainers(ctx, project.Name, oneOffExclude, false, services...)
+	if err != nil {
+		return 0, err
+	}
+	if len(containers) == 0 {
+		return 0, fmt.Errorf("no running container for service(s) %s", strings.Join(services, ", "))
+	}
+	containers.sorted() // This enforce predictable colors assignment
+
+	exitCodes := make([]int, len(containers))
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, c := range containers {
+		i, container := i, c
+		name := getContainerNameWithoutProject(container)
+		opts.Consumer.Register(name)
+		eg.Go(func() error {
+			service, err := project.GetService(container.Labels[compose.ServiceLabel])
+			if err != nil {
+				return err
+			}
+			exitCode, err := s.execInContainer(ctx, project, service, container, opts)
+			if err != nil {
+				return err
+			}
+			exitCodes[i] = exitCode
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return 0, err
+	}
+
+	exitCode := 0
+	for i, c := range containers {
+		name := getContainerNameWithoutProject(c)
+		if !opts.Detach {
+			opts.Consumer.Status(name, fmt.Sprintf("exited with code %d", exitCodes[i]))
+		}
+		if exitCodes[i] > exitCode {
+			exitCode = exitCodes[i]
+		}
+	}
+	return exitCode, nil
+}
+
+// execInContainer runs a non-interactive command, and forwards output to opts.Consumer
+func (s *composeService) execInContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, container moby.Container, opts compose.RunOptions) (int, error) {
+	exec, err := s.apiClient.ContainerExecCreate(ctx, container.ID, moby.ExecConfig{
+		Cmd:        opts.Command,
+		Env:        s.getExecEnvironment(project, service, opts),
+		User:       opts.User,
+		Privileged: opts.Privileged,
+		Detach:     opts.Detach,
+		WorkingDir: opts.WorkingDir,
+
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.Detach {
+		return 0, s.apiClient.ContainerExecStart(ctx, exec.ID, moby.ExecStartCheck{
+			Detach: true,
+		})
+	}
+
+	resp, err := s.apiClient.ContainerExecAttach(ctx, exec.ID, moby.ExecStartCheck{})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	name := getContainerNameWithoutProject(container)
+	w := utils.GetWriter(func(line string) {
+		opts.Consumer.Log(name, service.Name, line)
+	})
+	errWriter := utils.GetWriter(func(line string) {
+		opts.Consumer.Err(name, service.Name, line)
+	})
+	_, err = stdcopy.StdCopy(w, errWriter, resp.Reader)
+	if err != nil {
+		return 0, err
+	}
+	return s.getExecExitStatus(ctx, exec.ID)
+}
+
 // inspired by https://github.com/docker/cli/blob/master/cli/command/container/exec.go#L116
 func (s *composeService) interactiveExec(ctx context.Context, opts compose.RunOptions, resp moby.HijackedResponse) error {
 	outputDone := make(chan error)
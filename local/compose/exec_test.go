@@ -0,0 +1,112 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+type execLogConsumer struct {
+	sync.Mutex
+	lines  []string
+	status []string
+}
+
+func (l *execLogConsumer) Log(container, service, message string) {
+	l.Lock()
+	defer l.Unlock()
+	l.lines = append(l.lines, container+": "+message)
+}
+
+func (l *execLogConsumer) Err(container, service, message string) {
+	l.Log(container, service, message)
+}
+
+func (l *execLogConsumer) Status(container, msg string) {
+	l.status = append(l.status, container+": "+msg)
+}
+
+func (l *execLogConsumer) Register(container string) {}
+
+func TestExecAll(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	project := types.Project{Name: testProject, Services: []types.ServiceConfig{testService("service1"), testService("service2")}}
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, apitypes.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(testProject), oneOffFilter(false)),
+	}).Return([]apitypes.Container{execContainer("service1", "123", 1), execContainer("service1", "456", 2), execContainer("service2", "789", 1)}, nil)
+
+	exitCodes := map[string]int{"123": 0, "456": 2, "789": 1}
+	for id, exitCode := range exitCodes {
+		api.EXPECT().ContainerExecCreate(gomock.Any(), id, gomock.Any()).Return(apitypes.IDResponse{ID: "exec" + id}, nil)
+		api.EXPECT().ContainerExecAttach(gomock.Any(), "exec"+id, apitypes.ExecStartCheck{}).Return(execOutput(t, "hello from "+id), nil)
+		api.EXPECT().ContainerExecInspect(gomock.Any(), "exec"+id).Return(apitypes.ContainerExecInspect{ExitCode: exitCode}, nil)
+	}
+
+	consumer := &execLogConsumer{}
+	exitCode, err := tested.Exec(ctx, &project, compose.RunOptions{
+		All:      true,
+		Services: []string{"service1", "service2"},
+		Command:  []string{"echo"},
+		Consumer: consumer,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 2)
+
+	sort.Strings(consumer.lines)
+	assert.DeepEqual(t, consumer.lines, []string{"service1_1: hello from 123", "service1_2: hello from 456", "service2_1: hello from 789"})
+	assert.DeepEqual(t, consumer.status, []string{"service1_1: exited with code 0", "service1_2: exited with code 2", "service2_1: exited with code 1"})
+}
+
+func execContainer(service string, id string, number int) apitypes.Container {
+	c := testContainer(service, id)
+	c.Names = []string{fmt.Sprintf("/%s_%s_%d", testProject, service, number)}
+	return c
+}
+
+func execOutput(t *testing.T, line string) apitypes.HijackedResponse {
+	buf := bytes.Buffer{}
+	_, err := stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte(line + "\n"))
+	assert.NilError(t, err)
+	conn, _ := net.Pipe()
+	return apitypes.HijackedResponse{
+		Conn:   conn,
+		Reader: bufio.NewReader(&buf),
+	}
+}
@@ -0,0 +1,44 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// newConcurrencyLimit creates a semaphore to bound the number of concurrent operations, like image pulls or pushes.
+// A nil semaphore is returned when limit is not set, so that operations are not limited.
+func newConcurrencyLimit(limit int) *semaphore.Weighted {
+	if limit <= 0 {
+		return nil
+	}
+	return semaphore.NewWeighted(int64(limit))
+}
+
+// withConcurrencyLimit runs fn once the limit semaphore has been acquired
+func withConcurrencyLimit(ctx context.Context, limit *semaphore.Weighted, fn func() error) error {
+	if limit == nil {
+		return fn()
+	}
+	if err := limit.Acquire(ctx, 1); err != nil {
+		return err
+	}
+	defer limit.Release(1)
+	return fn()
+}
@@ -31,6 +31,10 @@ import (
 func (s *composeService) Logs(ctx context.Context, projectName string, consumer compose.LogConsumer, options compose.LogOptions) error {
 	list, err := s.getContainers(ctx, projectName, oneOffExclude, true, options.Services...)
 
+	if err != nil {
+		return err
+	}
+	consumer, err = utils.GrepLogConsumer(consumer, options.Grep, options.Timestamps)
 	if err != nil {
 		return err
 	}
@@ -50,6 +54,8 @@ func (s *composeService) Logs(ctx context.Context, projectName string, consumer
 				Follow:     options.Follow,
 				Tail:       options.Tail,
 				Timestamps: options.Timestamps,
+				Since:      options.Since,
+				Until:      options.Until,
 			})
 			if err != nil {
 				return err
@@ -63,7 +69,10 @@ func (s *composeService) Logs(ctx context.Context, projectName string, consumer
 			if container.Config.Tty {
 				_, err = io.Copy(w, r)
 			} else {
-				_, err = stdcopy.StdCopy(w, w, r)
+				errWriter := utils.GetWriter(func(line string) {
+					consumer.Err(name, service, line)
+				})
+				_, err = stdcopy.StdCopy(w, errWriter, r)
 			}
 			return err
 		})
@@ -21,9 +21,12 @@ import (
 	"fmt"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/errdefs"
 
+	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
 )
 
 func (s *composeService) Port(ctx context.Context, project string, service string, port int, options compose.PortOptions) (string, int, error) {
@@ -48,3 +51,8 @@ func (s *composeService) Port(ctx context.Context, project string, service strin
 	}
 	return "", 0, err
 }
+
+// PortForward is not needed with the local engine, which publishes ports on the host
+func (s *composeService) PortForward(ctx context.Context, project *types.Project, options compose.PortForwardOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "ports are published by the Docker engine, see `compose port`")
+}
@@ -106,6 +106,10 @@ func (p *printer) Run(cascadeStop bool, exitCodeFrom string, stopFn func() error
 			if !aborting {
 				p.consumer.Log(container, event.Service, event.Line)
 			}
+		case compose.ContainerEventErr:
+			if !aborting {
+				p.consumer.Err(container, event.Service, event.Line)
+			}
 		}
 	}
 }
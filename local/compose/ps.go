@@ -22,6 +22,7 @@ import (
 	"sort"
 
 	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -34,6 +35,12 @@ func (s *composeService) Ps(ctx context.Context, projectName string, options com
 	if err != nil {
 		return nil, err
 	}
+	if len(options.Status) > 0 {
+		containers = containers.filter(hasStatus(options.Status...))
+	}
+	if len(options.Labels) > 0 {
+		containers = containers.filter(hasLabels(options.Labels...))
+	}
 
 	summary := make([]compose.ContainerSummary, len(containers))
 	eg, ctx := errgroup.WithContext(ctx)
@@ -78,18 +85,45 @@ func (s *composeService) Ps(ctx context.Context, projectName string, options com
 				}
 			}
 
+			var networks []string
+			if container.NetworkSettings != nil {
+				for name := range container.NetworkSettings.Networks {
+					networks = append(networks, name)
+				}
+				sort.Strings(networks)
+			}
+
 			summary[i] = compose.ContainerSummary{
 				ID:         container.ID,
 				Name:       getCanonicalContainerName(container),
+				Image:      container.Image,
+				Command:    container.Command,
 				Project:    container.Labels[compose.ProjectLabel],
 				Service:    container.Labels[compose.ServiceLabel],
+				Created:    container.Created,
 				State:      container.State,
 				Health:     health,
 				ExitCode:   exitCode,
 				Publishers: publishers,
+				Networks:   networks,
 			}
 			return nil
 		})
 	}
-	return summary, eg.Wait()
+	err = eg.Wait()
+	if err != nil || len(options.Health) == 0 {
+		return summary, err
+	}
+
+	var filtered []compose.ContainerSummary
+	for _, c := range summary {
+		health := c.Health
+		if health == "" {
+			health = "none"
+		}
+		if utils.StringContains(options.Health, health) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
 }
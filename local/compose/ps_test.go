@@ -25,6 +25,7 @@ import (
 
 	apitypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/local/mocks"
@@ -60,6 +61,39 @@ func TestPs(t *testing.T) {
 	assert.DeepEqual(t, containers, expected)
 }
 
+func TestPsFilters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	args := filters.NewArgs(projectFilter(testProject))
+	args.Add("label", "com.docker.compose.oneoff=False")
+	listOpts := apitypes.ContainerListOptions{Filters: args, All: true}
+	c1, inspect1 := containerDetails("service1", "123", "running", "healthy", 0)
+	c1.Labels["tier"] = "front"
+	c1.Image = "nginx"
+	c1.NetworkSettings = &apitypes.SummaryNetworkSettings{Networks: map[string]*network.EndpointSettings{"front": {}, "back": {}}}
+	c2, _ := containerDetails("service1", "456", "running", "", 0)
+	c3, _ := containerDetails("service2", "789", "exited", "", 130)
+	c3.Labels["tier"] = "front"
+	api.EXPECT().ContainerList(ctx, listOpts).Return([]apitypes.Container{c1, c2, c3}, nil)
+	api.EXPECT().ContainerInspect(anyCancellableContext(), "123").Return(inspect1, nil)
+
+	containers, err := tested.Ps(ctx, testProject, compose.PsOptions{
+		Status: []string{"running"},
+		Health: []string{"healthy"},
+		Labels: []string{"tier=front"},
+	})
+
+	expected := []compose.ContainerSummary{
+		{ID: "123", Name: "123", Image: "nginx", Project: testProject, Service: "service1", State: "running", Health: "healthy", Networks: []string{"back", "front"}},
+	}
+	assert.NilError(t, err)
+	assert.DeepEqual(t, containers, expected)
+}
+
 func containerDetails(service string, id string, status string, health string, exitCode int) (apitypes.Container, apitypes.ContainerJSON) {
 	container := apitypes.Container{
 		ID:     id,
@@ -21,13 +21,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/distribution/distribution/v3/reference"
 	"github.com/docker/buildx/driver"
 	moby "github.com/docker/docker/api/types"
+	mobyerrdefs "github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/registry"
 	"golang.org/x/sync/errgroup"
@@ -56,6 +60,8 @@ func (s *composeService) pull(ctx context.Context, project *types.Project, opts
 		info.IndexServerAddress = registry.IndexServer
 	}
 
+	history := loadPullHistory(info.ID)
+	limit := newConcurrencyLimit(opts.Parallelism)
 	w := progress.ContextWriter(ctx)
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -70,20 +76,35 @@ func (s *composeService) pull(ctx context.Context, project *types.Project, opts
 			continue
 		}
 		eg.Go(func() error {
-			err := s.pullServiceImage(ctx, service, info, s.configFile, w, false)
+			err := withConcurrencyLimit(ctx, limit, func() error {
+				return s.pullServiceImage(ctx, service, info, s.configFile, w, false)
+			})
 			if err != nil {
 				if !opts.IgnoreFailures {
 					return err
 				}
 				w.TailMsgf("Pulling %s: %s", service.Name, err.Error())
+				return nil
 			}
+			history.record(service.Image, time.Now())
 			return nil
 		})
 	}
 
-	return eg.Wait()
+	err = eg.Wait()
+	history.save()
+	return err
 }
 
+const (
+	// pullMaxAttempts is the number of attempts to pull an image before transient errors are reported
+	pullMaxAttempts = 5
+	// pullInitialBackoff is the delay before the first retry, doubled on each subsequent attempt
+	pullInitialBackoff = time.Second
+	// pullMaxBackoff caps the delay between two attempts
+	pullMaxBackoff = 16 * time.Second
+)
+
 func (s *composeService) pullServiceImage(ctx context.Context, service types.ServiceConfig, info moby.Info, configFile driver.Auth, w progress.Writer, quietPull bool) error {
 	w.Event(progress.Event{
 		ID:     service.Name,
@@ -114,45 +135,177 @@ func (s *composeService) pullServiceImage(ctx context.Context, service types.Ser
 	if err != nil {
 		return err
 	}
+	registryAuth := base64.URLEncoding.EncodeToString(buf)
 
+	backoff := pullInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err = s.doPullImage(ctx, service, registryAuth, w, quietPull)
+		if err == nil {
+			break
+		}
+		if attempt >= pullMaxAttempts || ctx.Err() != nil || !isTransientPullError(err) {
+			w.Event(progress.Event{
+				ID:     service.Name,
+				Status: progress.Error,
+				Text:   "Error",
+			})
+			return metrics.WrapCategorisedComposeError(err, metrics.PullFailure)
+		}
+		w.Event(progress.Event{
+			ID:         service.Name,
+			Status:     progress.Working,
+			Text:       "Pulling",
+			StatusText: fmt.Sprintf("%s, retrying in %s", err.Error(), backoff),
+		})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > pullMaxBackoff {
+			backoff = pullMaxBackoff
+		}
+	}
+	w.Event(progress.Event{
+		ID:     service.Name,
+		Status: progress.Done,
+		Text:   "Pulled",
+	})
+	return nil
+}
+
+func (s *composeService) doPullImage(ctx context.Context, service types.ServiceConfig, registryAuth string, w progress.Writer, quietPull bool) error {
 	stream, err := s.apiClient.ImagePull(ctx, service.Image, moby.ImagePullOptions{
-		RegistryAuth: base64.URLEncoding.EncodeToString(buf),
+		RegistryAuth: registryAuth,
 		Platform:     service.Platform,
 	})
 	if err != nil {
-		w.Event(progress.Event{
-			ID:     service.Name,
-			Status: progress.Error,
-			Text:   "Error",
-		})
-		return metrics.WrapCategorisedComposeError(err, metrics.PullFailure)
+		return err
 	}
+	defer stream.Close() //nolint:errcheck
 
 	dec := json.NewDecoder(stream)
 	for {
 		var jm jsonmessage.JSONMessage
 		if err := dec.Decode(&jm); err != nil {
 			if err == io.EOF {
-				break
+				return nil
 			}
-			return metrics.WrapCategorisedComposeError(err, metrics.PullFailure)
+			return err
 		}
 		if jm.Error != nil {
-			return metrics.WrapCategorisedComposeError(errors.New(jm.Error.Message), metrics.PullFailure)
+			return errors.New(jm.Error.Message)
 		}
 		if !quietPull {
 			toPullProgressEvent(service.Name, jm, w)
 		}
 	}
-	w.Event(progress.Event{
-		ID:     service.Name,
-		Status: progress.Done,
-		Text:   "Pulled",
-	})
-	return nil
 }
 
-func (s *composeService) pullRequiredImages(ctx context.Context, project *types.Project, images map[string]string, quietPull bool) error {
+// transientPullErrors are registry error messages reported by the engine as plain text, which are worth a retry
+var transientPullErrors = []string{
+	"toomanyrequests",
+	"too many requests",
+	"timeout",
+	"timed out",
+	"connection reset by peer",
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+// isTransientPullError tells if a pull failure is likely to be resolved by trying again later
+func isTransientPullError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if mobyerrdefs.IsUnavailable(err) || mobyerrdefs.IsDeadline(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, transient := range transientPullErrors {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// pullPolicyDaily pulls images once a day
+	pullPolicyDaily = "daily"
+	// pullPolicyEveryPrefix is the prefix for `every_<duration>` pull policies, i.e. `every_12h`
+	pullPolicyEveryPrefix = "every_"
+	// pullPolicyRefresh is the internal pull policy for images pulled on a regular basis
+	pullPolicyRefresh = "refresh"
+	// pullPolicyExtension sets the service pull policy, overriding pull_policy. The compose file schema only
+	// accepts `daily` and `every_<duration>` policies through this extension.
+	pullPolicyExtension = "x-pull-policy"
+)
+
+// parsePullPolicy normalizes service pull_policy and, for policies which refresh images on a regular basis,
+// returns the interval between two pulls
+func parsePullPolicy(service types.ServiceConfig) (string, time.Duration, error) {
+	policy := service.PullPolicy
+	if x, ok := service.Extensions[pullPolicyExtension]; ok {
+		value, ok := x.(string)
+		if !ok {
+			return "", 0, fmt.Errorf("service %q: %s must be a string", service.Name, pullPolicyExtension)
+		}
+		policy = value
+	}
+	switch {
+	case policy == "", policy == types.PullPolicyMissing, policy == types.PullPolicyIfNotPresent:
+		return types.PullPolicyMissing, 0, nil
+	case policy == types.PullPolicyAlways, policy == types.PullPolicyNever, policy == types.PullPolicyBuild:
+		return policy, 0, nil
+	case policy == pullPolicyDaily:
+		return pullPolicyRefresh, 24 * time.Hour, nil
+	case strings.HasPrefix(policy, pullPolicyEveryPrefix):
+		interval, err := time.ParseDuration(strings.TrimPrefix(policy, pullPolicyEveryPrefix))
+		if err != nil || interval <= 0 {
+			return "", 0, fmt.Errorf("service %q: invalid pull_policy %q, expected %s<duration> like %s12h", service.Name, policy, pullPolicyEveryPrefix, pullPolicyEveryPrefix)
+		}
+		return pullPolicyRefresh, interval, nil
+	default:
+		return "", 0, fmt.Errorf("service %q: unsupported pull_policy %q", service.Name, policy)
+	}
+}
+
+// mustPull tells if service image has to be pulled according to its pull_policy, the local images and the last
+// time compose pulled the image
+func mustPull(service types.ServiceConfig, images map[string]string, history *pullHistory, now time.Time) (bool, error) {
+	if service.Image == "" {
+		return false, nil
+	}
+	policy, interval, err := parsePullPolicy(service)
+	if err != nil {
+		return false, err
+	}
+	_, present := images[service.Image]
+	switch policy {
+	case types.PullPolicyAlways:
+		return true, nil
+	case types.PullPolicyNever, types.PullPolicyBuild:
+		return false, nil
+	case pullPolicyRefresh:
+		if !present {
+			return true, nil
+		}
+		last, ok := history.lastPull(service.Image)
+		return !ok || now.Sub(last) >= interval, nil
+	default:
+		return !present, nil
+	}
+}
+
+func (s *composeService) pullRequiredImages(ctx context.Context, project *types.Project, images map[string]string, quietPull bool, parallelism int) error {
 	info, err := s.apiClient.Info(ctx)
 	if err != nil {
 		return err
@@ -162,43 +315,46 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 		info.IndexServerAddress = registry.IndexServer
 	}
 
+	history := loadPullHistory(info.ID)
+	now := time.Now()
 	var needPull []types.ServiceConfig
 	for _, service := range project.Services {
-		if service.Image == "" {
-			continue
+		pull, err := mustPull(service, images, history, now)
+		if err != nil {
+			return err
 		}
-		switch service.PullPolicy {
-		case "", types.PullPolicyMissing, types.PullPolicyIfNotPresent:
-			if _, ok := images[service.Image]; ok {
-				continue
-			}
-		case types.PullPolicyNever, types.PullPolicyBuild:
-			continue
-		case types.PullPolicyAlways:
-			// force pull
+		if pull {
+			needPull = append(needPull, service)
 		}
-		needPull = append(needPull, service)
 	}
 	if len(needPull) == 0 {
 		return nil
 	}
 
-	return progress.Run(ctx, func(ctx context.Context) error {
+	err = progress.Run(ctx, func(ctx context.Context) error {
 		w := progress.ContextWriter(ctx)
+		limit := newConcurrencyLimit(parallelism)
 		eg, ctx := errgroup.WithContext(ctx)
 		for _, service := range needPull {
 			service := service
 			eg.Go(func() error {
-				err := s.pullServiceImage(ctx, service, info, s.configFile, w, quietPull)
+				err := withConcurrencyLimit(ctx, limit, func() error {
+					return s.pullServiceImage(ctx, service, info, s.configFile, w, quietPull)
+				})
 				if err != nil && service.Build != nil {
 					// image can be built, so we can ignore pull failure
 					return nil
 				}
+				if err == nil {
+					history.record(service.Image, time.Now())
+				}
 				return err
 			})
 		}
 		return eg.Wait()
 	})
+	history.save()
+	return err
 }
 
 func toPullProgressEvent(parent string, jm jsonmessage.JSONMessage, w progress.Writer) {
@@ -0,0 +1,104 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/config"
+)
+
+// pullHistoryFileName is the file, within the config directory, in which compose records image pulls
+const pullHistoryFileName = "compose-pulls.json"
+
+// pullHistory records the last time images were pulled on a Docker engine, so that pull policies like `daily`
+// or `every_12h` can tell when an image needs to be refreshed
+type pullHistory struct {
+	mu     sync.Mutex
+	path   string
+	engine string
+	// pulls maps engine IDs to image references and time they were last pulled
+	pulls map[string]map[string]time.Time
+}
+
+func loadPullHistory(engine string) *pullHistory {
+	history := &pullHistory{
+		engine: engine,
+		pulls:  map[string]map[string]time.Time{},
+	}
+	if dir := config.Dir(); dir != "" {
+		history.path = filepath.Join(dir, pullHistoryFileName)
+	}
+	history.load()
+	return history
+}
+
+func (h *pullHistory) load() {
+	if h.path == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(h.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Warnf("cannot read pull history: %v", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &h.pulls); err != nil {
+		logrus.Warnf("ignoring invalid pull history %s: %v", h.path, err)
+		h.pulls = map[string]map[string]time.Time{}
+	}
+}
+
+func (h *pullHistory) lastPull(image string) (time.Time, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t, ok := h.pulls[h.engine][image]
+	return t, ok
+}
+
+func (h *pullHistory) record(image string, t time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.pulls[h.engine]; !ok {
+		h.pulls[h.engine] = map[string]time.Time{}
+	}
+	h.pulls[h.engine][image] = t
+}
+
+// save persists pull history. As history is only used to optimize pulls, failures are reported as warnings
+func (h *pullHistory) save() {
+	if h.path == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	data, err := json.MarshalIndent(h.pulls, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(h.path, data, 0644)
+	}
+	if err != nil {
+		logrus.Warnf("cannot save pull history: %v", err)
+	}
+}
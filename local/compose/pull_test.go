@@ -0,0 +1,147 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestParsePullPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected string
+		interval time.Duration
+	}{
+		{"", types.PullPolicyMissing, 0},
+		{types.PullPolicyIfNotPresent, types.PullPolicyMissing, 0},
+		{types.PullPolicyMissing, types.PullPolicyMissing, 0},
+		{types.PullPolicyAlways, types.PullPolicyAlways, 0},
+		{types.PullPolicyNever, types.PullPolicyNever, 0},
+		{types.PullPolicyBuild, types.PullPolicyBuild, 0},
+		{"daily", pullPolicyRefresh, 24 * time.Hour},
+		{"every_12h", pullPolicyRefresh, 12 * time.Hour},
+		{"every_90m", pullPolicyRefresh, 90 * time.Minute},
+	}
+	for _, tc := range testCases {
+		policy, interval, err := parsePullPolicy(types.ServiceConfig{Name: "test", PullPolicy: tc.policy})
+		assert.NilError(t, err)
+		assert.Equal(t, policy, tc.expected)
+		assert.Equal(t, interval, tc.interval)
+	}
+
+	_, _, err := parsePullPolicy(types.ServiceConfig{Name: "test", PullPolicy: "every_day"})
+	assert.ErrorContains(t, err, `invalid pull_policy "every_day"`)
+	_, _, err = parsePullPolicy(types.ServiceConfig{Name: "test", PullPolicy: "sometimes"})
+	assert.ErrorContains(t, err, `unsupported pull_policy "sometimes"`)
+}
+
+func TestPullPolicyExtension(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`
+services:
+  daily:
+    image: nginx
+    x-pull-policy: daily
+  every:
+    image: nginx
+    pull_policy: always
+    x-pull-policy: every_12h
+  plain:
+    image: nginx
+    pull_policy: if_not_present
+`))
+	assert.NilError(t, err)
+	project, err := loader.Load(types.ConfigDetails{
+		WorkingDir:  ".",
+		ConfigFiles: []types.ConfigFile{{Filename: "compose.yaml", Config: dict}},
+	})
+	assert.NilError(t, err)
+
+	expected := map[string]struct {
+		policy   string
+		interval time.Duration
+	}{
+		"daily": {pullPolicyRefresh, 24 * time.Hour},
+		"every": {pullPolicyRefresh, 12 * time.Hour},
+		"plain": {types.PullPolicyMissing, 0},
+	}
+	for _, service := range project.Services {
+		policy, interval, err := parsePullPolicy(service)
+		assert.NilError(t, err)
+		assert.Equal(t, policy, expected[service.Name].policy, service.Name)
+		assert.Equal(t, interval, expected[service.Name].interval, service.Name)
+	}
+
+	_, _, err = parsePullPolicy(types.ServiceConfig{Name: "test", Extensions: map[string]interface{}{pullPolicyExtension: 12}})
+	assert.ErrorContains(t, err, "x-pull-policy must be a string")
+}
+
+func TestMustPull(t *testing.T) {
+	now := time.Now()
+	history := &pullHistory{
+		engine: "engine",
+		pulls: map[string]map[string]time.Time{
+			"engine": {
+				"fresh": now.Add(-time.Hour),
+				"stale": now.Add(-48 * time.Hour),
+			},
+		},
+	}
+	images := map[string]string{"fresh": "sha256:1", "stale": "sha256:2", "unknown": "sha256:3"}
+
+	testCases := []struct {
+		image    string
+		policy   string
+		expected bool
+	}{
+		{"fresh", "", false},
+		{"missing", "", true},
+		{"missing", types.PullPolicyNever, false},
+		{"missing", types.PullPolicyBuild, false},
+		{"fresh", types.PullPolicyAlways, true},
+		{"fresh", "daily", false},
+		{"stale", "daily", true},
+		{"unknown", "daily", true},
+		{"missing", "daily", true},
+		{"fresh", "every_30m", true},
+	}
+	for _, tc := range testCases {
+		pull, err := mustPull(types.ServiceConfig{Image: tc.image, PullPolicy: tc.policy}, images, history, now)
+		assert.NilError(t, err)
+		assert.Equal(t, pull, tc.expected, "image %s with pull_policy %q", tc.image, tc.policy)
+	}
+
+	pull, err := mustPull(types.ServiceConfig{Build: &types.BuildConfig{}}, images, history, now)
+	assert.NilError(t, err)
+	assert.Assert(t, !pull)
+}
+
+func TestIsTransientPullError(t *testing.T) {
+	assert.Assert(t, isTransientPullError(errors.New("toomanyrequests: You have reached your pull rate limit")))
+	assert.Assert(t, isTransientPullError(errors.New("received unexpected HTTP status: 503 Service Unavailable")))
+	assert.Assert(t, isTransientPullError(errors.New("net/http: TLS handshake timeout")))
+	assert.Assert(t, isTransientPullError(context.DeadlineExceeded))
+	assert.Assert(t, !isTransientPullError(context.Canceled))
+	assert.Assert(t, !isTransientPullError(errors.New("pull access denied for foo, repository does not exist")))
+	assert.Assert(t, !isTransientPullError(nil))
+}
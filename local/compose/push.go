@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/distribution/distribution/v3/reference"
@@ -32,6 +33,7 @@ import (
 	"github.com/docker/docker/registry"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/api/config"
@@ -60,6 +62,8 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 	}
 
 	w := progress.ContextWriter(ctx)
+	limits := map[string]*semaphore.Weighted{}
+	var mu sync.Mutex
 	for _, service := range project.Services {
 		if service.Build == nil || service.Image == "" {
 			w.Event(progress.Event{
@@ -70,13 +74,36 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 			continue
 		}
 		service := service
+		refs, err := getPushReferences(service.Image, options.Tags)
+		if err != nil {
+			return err
+		}
+		// all references of a service image share the same repository, so the same registry
+		reg := refs[0].Index.Name
+		if _, ok := limits[reg]; !ok {
+			limits[reg] = newConcurrencyLimit(options.Parallelism)
+		}
+		limit := limits[reg]
 		eg.Go(func() error {
-			err := s.pushServiceImage(ctx, service, info, configFile, w)
-			if err != nil {
-				if !options.IgnoreFailures {
+			for _, ref := range refs {
+				var summary compose.PushSummary
+				err := withConcurrencyLimit(ctx, limit, func() error {
+					var err error
+					summary, err = s.pushServiceImage(ctx, service, ref, info, configFile, w)
 					return err
+				})
+				if err != nil {
+					if !options.IgnoreFailures {
+						return err
+					}
+					w.TailMsgf("Pushing %s: %s", service.Name, err.Error())
+					continue
+				}
+				if options.Summary != nil {
+					mu.Lock()
+					options.Summary(summary)
+					mu.Unlock()
 				}
-				w.TailMsgf("Pushing %s: %s", service.Name, err.Error())
 			}
 			return nil
 		})
@@ -84,37 +111,85 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 	return eg.Wait()
 }
 
-func (s *composeService) pushServiceImage(ctx context.Context, service types.ServiceConfig, info moby.Info, configFile driver.Auth, w progress.Writer) error {
-	ref, err := reference.ParseNormalizedNamed(service.Image)
+// pushReference is an image reference to be pushed and the registry it belongs to
+type pushReference struct {
+	*registry.RepositoryInfo
+	reference.Named
+	// additional is set for tags requested on top of service image, which have to be applied before push
+	additional bool
+}
+
+// getPushReferences returns image reference followed by the additional tags to be pushed for it
+func getPushReferences(image string, tags []string) ([]pushReference, error) {
+	ref, err := reference.ParseNormalizedNamed(image)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
 	repoInfo, err := registry.ParseRepositoryInfo(ref)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	refs := []pushReference{{RepositoryInfo: repoInfo, Named: ref}}
+	for _, tag := range tags {
+		tagged, err := reference.WithTag(reference.TrimNamed(ref), tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tag %q", tag)
+		}
+		if tagged.String() == reference.TagNameOnly(ref).String() {
+			continue
+		}
+		refs = append(refs, pushReference{RepositoryInfo: repoInfo, Named: tagged, additional: true})
+	}
+	return refs, nil
+}
 
-	key := repoInfo.Index.Name
-	if repoInfo.Index.Official {
+func (s *composeService) pushServiceImage(ctx context.Context, service types.ServiceConfig, ref pushReference, info moby.Info, configFile driver.Auth, w progress.Writer) (compose.PushSummary, error) {
+	image := reference.FamiliarString(ref.Named)
+	summary := compose.PushSummary{
+		Service: service.Name,
+		Image:   image,
+	}
+
+	key := ref.Index.Name
+	if ref.Index.Official {
 		key = info.IndexServerAddress
 	}
 	authConfig, err := configFile.GetAuthConfig(key)
 	if err != nil {
-		return err
+		return summary, err
 	}
 
 	buf, err := json.Marshal(authConfig)
 	if err != nil {
-		return err
+		return summary, err
 	}
+	registryAuth := base64.URLEncoding.EncodeToString(buf)
 
-	stream, err := s.apiClient.ImagePush(ctx, service.Image, moby.ImagePushOptions{
-		RegistryAuth: base64.URLEncoding.EncodeToString(buf),
+	if ref.additional {
+		if err := s.apiClient.ImageTag(ctx, service.Image, image); err != nil {
+			return summary, err
+		}
+	}
+
+	if digest, ok := s.getUpToDateRemoteDigest(ctx, ref, registryAuth); ok {
+		w.Event(progress.Event{
+			ID:         fmt.Sprintf("Pushing %s", image),
+			Status:     progress.Done,
+			Text:       "Skipped",
+			StatusText: "Remote image is up to date",
+		})
+		summary.Digest = digest
+		summary.Skipped = true
+		return summary, nil
+	}
+
+	stream, err := s.apiClient.ImagePush(ctx, image, moby.ImagePushOptions{
+		RegistryAuth: registryAuth,
 	})
 	if err != nil {
-		return err
+		return summary, err
 	}
+	defer stream.Close() //nolint:errcheck
 	dec := json.NewDecoder(stream)
 	for {
 		var jm jsonmessage.JSONMessage
@@ -122,14 +197,42 @@ func (s *composeService) pushServiceImage(ctx context.Context, service types.Ser
 			if err == io.EOF {
 				break
 			}
-			return err
+			return summary, err
 		}
 		if jm.Error != nil {
-			return errors.New(jm.Error.Message)
+			return summary, errors.New(jm.Error.Message)
+		}
+		if jm.Aux != nil {
+			var result moby.PushResult
+			if err := json.Unmarshal(*jm.Aux, &result); err == nil {
+				summary.Digest = result.Digest
+			}
 		}
 		toPushProgressEvent(service.Name, jm, w)
 	}
-	return nil
+	return summary, nil
+}
+
+// getUpToDateRemoteDigest checks the registry for the manifest digest of ref, and tells if the local image
+// has already been pushed with this digest
+func (s *composeService) getUpToDateRemoteDigest(ctx context.Context, ref pushReference, registryAuth string) (string, bool) {
+	remote, err := s.apiClient.DistributionInspect(ctx, ref.String(), registryAuth)
+	if err != nil {
+		// image is not available on the registry, or registry can't tell
+		return "", false
+	}
+	local, _, err := s.apiClient.ImageInspectWithRaw(ctx, ref.String())
+	if err != nil {
+		return "", false
+	}
+	digest := remote.Descriptor.Digest.String()
+	expected := reference.FamiliarName(ref.Named) + "@" + digest
+	for _, repoDigest := range local.RepoDigests {
+		if repoDigest == expected {
+			return digest, true
+		}
+	}
+	return "", false
 }
 
 func toPushProgressEvent(prefix string, jm jsonmessage.JSONMessage, w progress.Writer) {
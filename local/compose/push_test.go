@@ -0,0 +1,79 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/golang/mock/gomock"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestGetPushReferences(t *testing.T) {
+	refs, err := getPushReferences("registry.example.com/org/app", []string{"v1.2.3", "latest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(refs), 2)
+	assert.Equal(t, refs[0].String(), "registry.example.com/org/app")
+	assert.Equal(t, refs[0].additional, false)
+	assert.Equal(t, refs[1].String(), "registry.example.com/org/app:v1.2.3")
+	assert.Equal(t, refs[1].additional, true)
+	assert.Equal(t, refs[1].Index.Name, "registry.example.com")
+
+	refs, err = getPushReferences("app:1.0", []string{"1.0", "stable"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(refs), 2)
+	assert.Equal(t, refs[1].String(), "docker.io/library/app:stable")
+
+	_, err = getPushReferences("app", []string{"not a tag"})
+	assert.ErrorContains(t, err, `invalid tag "not a tag"`)
+}
+
+func TestGetUpToDateRemoteDigest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	refs, err := getPushReferences("org/app:1.0", nil)
+	assert.NilError(t, err)
+	ctx := context.Background()
+	remote := registrytypes.DistributionInspect{
+		Descriptor: specs.Descriptor{Digest: digest.Digest("sha256:abcd")},
+	}
+
+	api.EXPECT().DistributionInspect(ctx, "docker.io/org/app:1.0", "auth").Return(remote, nil)
+	api.EXPECT().ImageInspectWithRaw(ctx, "docker.io/org/app:1.0").Return(moby.ImageInspect{
+		RepoDigests: []string{"org/app@sha256:abcd"},
+	}, nil, nil)
+	d, ok := tested.getUpToDateRemoteDigest(ctx, refs[0], "auth")
+	assert.Assert(t, ok)
+	assert.Equal(t, d, "sha256:abcd")
+
+	api.EXPECT().DistributionInspect(ctx, "docker.io/org/app:1.0", "auth").Return(remote, nil)
+	api.EXPECT().ImageInspectWithRaw(ctx, "docker.io/org/app:1.0").Return(moby.ImageInspect{
+		RepoDigests: []string{"org/app@sha256:0123"},
+	}, nil, nil)
+	_, ok = tested.getUpToDateRemoteDigest(ctx, refs[0], "auth")
+	assert.Assert(t, !ok)
+}
@@ -53,7 +53,7 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 	service.Labels = service.Labels.Add(compose.SlugLabel, slug)
 	service.Labels = service.Labels.Add(compose.OneoffLabel, "True")
 
-	if err := s.ensureImagesExists(ctx, project, observedState, false); err != nil { // all dependencies already checked, but might miss service img
+	if err := s.ensureImagesExists(ctx, project, observedState, false, 0); err != nil { // all dependencies already checked, but might miss service img
 		return 0, err
 	}
 	if err := s.waitDependencies(ctx, project, service); err != nil {
@@ -81,7 +81,7 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 		return 0, err
 	}
 	oneoffContainer := containers[0]
-	restore, err := s.attachContainerStreams(ctx, oneoffContainer.ID, service.Tty, opts.Reader, opts.Writer)
+	restore, err := s.attachContainerStreams(ctx, oneoffContainer.ID, service.Tty, opts.Reader, opts.Writer, opts.Writer)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,315 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/stringid"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// serviceReferences selects first replica of each service as reference, and counts replicas
+func serviceReferences(containers Containers) (map[string]moby.Container, map[string]int) {
+	replicas := map[string]int{}
+	references := map[string]moby.Container{}
+	for _, c := range containers {
+		service := c.Labels[compose.ServiceLabel]
+		replicas[service]++
+		if ref, ok := references[service]; !ok || containerNumber(c) < containerNumber(ref) {
+			references[service] = c
+		}
+	}
+	return references, replicas
+}
+
+// projectFromRunningContainers rebuilds a project model from deployed containers, networks and volumes
+func (s *composeService) projectFromRunningContainers(ctx context.Context, projectName string, services []string) (*types.Project, error) {
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, true, services...)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no container found for project %q", projectName)
+	}
+
+	references, replicas := serviceReferences(containers)
+	options, err := loadProjectOptionsFromLabels(containers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	project := &types.Project{
+		Name:         projectName,
+		WorkingDir:   options.WorkingDir,
+		ComposeFiles: options.ConfigPaths,
+		Networks:     types.Networks{},
+		Volumes:      types.Volumes{},
+	}
+
+	networks, err := s.apiClient.NetworkList(ctx, moby.NetworkListOptions{Filters: filters.NewArgs(projectFilter(projectName))})
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		project.Networks[n.Labels[compose.NetworkLabel]] = types.NetworkConfig{
+			Name:       n.Name,
+			Driver:     n.Driver,
+			DriverOpts: n.Options,
+			Internal:   n.Internal,
+			Attachable: n.Attachable,
+			Labels:     withoutComposeLabels(n.Labels, nil),
+		}
+	}
+
+	volumes, err := s.apiClient.VolumeList(ctx, filters.NewArgs(projectFilter(projectName)))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes.Volumes {
+		project.Volumes[v.Labels[compose.VolumeLabel]] = types.VolumeConfig{
+			Name:       v.Name,
+			Driver:     v.Driver,
+			DriverOpts: v.Options,
+			Labels:     withoutComposeLabels(v.Labels, nil),
+		}
+	}
+
+	for name, c := range references {
+		service, err := s.serviceFromRunningContainer(ctx, project, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		service.Name = name
+		if replicas[name] > 1 {
+			service.Scale = replicas[name]
+		}
+		project.Services = append(project.Services, service)
+	}
+	sort.Slice(project.Services, func(i, j int) bool {
+		return project.Services[i].Name < project.Services[j].Name
+	})
+	return project, nil
+}
+
+func (s *composeService) serviceFromRunningContainer(ctx context.Context, project *types.Project, id string) (types.ServiceConfig, error) {
+	inspect, err := s.apiClient.ContainerInspect(ctx, id)
+	if err != nil {
+		return types.ServiceConfig{}, err
+	}
+	image, _, err := s.apiClient.ImageInspectWithRaw(ctx, inspect.Image)
+	if err != nil {
+		return types.ServiceConfig{}, err
+	}
+	imageConfig := image.Config
+	if imageConfig == nil {
+		imageConfig = &container.Config{}
+	}
+	config := inspect.Config
+	serviceName := config.Labels[compose.ServiceLabel]
+
+	service := types.ServiceConfig{
+		Image:       config.Image,
+		Labels:      withoutComposeLabels(config.Labels, imageConfig.Labels),
+		Environment: types.NewMappingWithEquals(without(config.Env, imageConfig.Env...)),
+		Tty:         config.Tty,
+		StdinOpen:   config.OpenStdin,
+		Privileged:  inspect.HostConfig.Privileged,
+		ReadOnly:    inspect.HostConfig.ReadonlyRootfs,
+		CapAdd:      inspect.HostConfig.CapAdd,
+		CapDrop:     inspect.HostConfig.CapDrop,
+		DNS:         inspect.HostConfig.DNS,
+		ExtraHosts:  inspect.HostConfig.ExtraHosts,
+		Tmpfs:       tmpfsList(inspect.HostConfig.Tmpfs),
+	}
+	if !stringSliceEqual(config.Cmd, imageConfig.Cmd) {
+		service.Command = types.ShellCommand(config.Cmd)
+	}
+	if !stringSliceEqual(config.Entrypoint, imageConfig.Entrypoint) {
+		service.Entrypoint = types.ShellCommand(config.Entrypoint)
+	}
+	if config.WorkingDir != imageConfig.WorkingDir {
+		service.WorkingDir = config.WorkingDir
+	}
+	if config.User != imageConfig.User {
+		service.User = config.User
+	}
+	containerName := strings.TrimPrefix(inspect.Name, "/")
+	number, _ := strconv.Atoi(config.Labels[compose.ContainerNumberLabel])
+	if containerName != getContainerName(project.Name, types.ServiceConfig{Name: serviceName}, number) {
+		service.ContainerName = containerName
+	}
+	if restart := inspect.HostConfig.RestartPolicy; restart.Name != "" && restart.Name != "no" {
+		service.Restart = restart.Name
+		if restart.MaximumRetryCount > 0 {
+			service.Restart = fmt.Sprintf("%s:%d", restart.Name, restart.MaximumRetryCount)
+		}
+	}
+
+	for port, bindings := range inspect.HostConfig.PortBindings {
+		for _, binding := range bindings {
+			published, _ := strconv.Atoi(binding.HostPort)
+			service.Ports = append(service.Ports, types.ServicePortConfig{
+				Mode:      "ingress",
+				HostIP:    binding.HostIP,
+				Target:    uint32(port.Int()),
+				Published: uint32(published),
+				Protocol:  port.Proto(),
+			})
+		}
+	}
+	// port bindings are collected from a map, sort them on every attribute for the model to be stable
+	sort.Slice(service.Ports, func(i, j int) bool {
+		a, b := service.Ports[i], service.Ports[j]
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.Published != b.Published {
+			return a.Published < b.Published
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		return a.HostIP < b.HostIP
+	})
+
+	for _, m := range inspect.Mounts {
+		volume := types.ServiceVolumeConfig{
+			Type:     string(m.Type),
+			Source:   m.Source,
+			Target:   m.Destination,
+			ReadOnly: !m.RW,
+		}
+		if m.Type == mount.TypeVolume {
+			volume.Source = ""
+			for name, v := range project.Volumes {
+				if v.Name == m.Name {
+					volume.Source = name
+				}
+			}
+			// volume not managed by this project, but not an anonymous one either
+			if volume.Source == "" && stringid.ValidateID(m.Name) != nil {
+				project.Volumes[m.Name] = types.VolumeConfig{
+					Name:     m.Name,
+					External: types.External{External: true},
+				}
+				volume.Source = m.Name
+			}
+		}
+		service.Volumes = append(service.Volumes, volume)
+	}
+	sort.Slice(service.Volumes, func(i, j int) bool {
+		return service.Volumes[i].Target < service.Volumes[j].Target
+	})
+
+	if mode := inspect.HostConfig.NetworkMode; !mode.IsUserDefined() && !mode.IsDefault() {
+		service.NetworkMode = string(mode)
+	}
+	for networkName, endpoint := range inspect.NetworkSettings.Networks {
+		key := networkName
+		for name, n := range project.Networks {
+			if n.Name == networkName {
+				key = name
+			}
+		}
+		if _, ok := project.Networks[key]; !ok {
+			if !inspect.HostConfig.NetworkMode.IsUserDefined() {
+				continue
+			}
+			project.Networks[key] = types.NetworkConfig{
+				Name:     networkName,
+				External: types.External{External: true},
+			}
+		}
+		if service.Networks == nil {
+			service.Networks = map[string]*types.ServiceNetworkConfig{}
+		}
+		var networkConfig *types.ServiceNetworkConfig
+		aliases := without(endpoint.Aliases, containerName, serviceName, stringid.TruncateID(inspect.ID))
+		if len(aliases) > 0 {
+			networkConfig = &types.ServiceNetworkConfig{Aliases: aliases}
+		}
+		service.Networks[key] = networkConfig
+	}
+	return service, nil
+}
+
+// withoutComposeLabels removes labels set by compose, and those inherited from image
+func withoutComposeLabels(labels map[string]string, inherited map[string]string) types.Labels {
+	var l types.Labels
+	for k, v := range labels {
+		if strings.HasPrefix(k, "com.docker.compose.") {
+			continue
+		}
+		if value, ok := inherited[k]; ok && value == v {
+			continue
+		}
+		l = l.Add(k, v)
+	}
+	return l
+}
+
+func tmpfsList(tmpfs map[string]string) types.StringList {
+	var list types.StringList
+	for path, options := range tmpfs {
+		if options != "" {
+			path = path + ":" + options
+		}
+		list = append(list, path)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func containerNumber(c moby.Container) int {
+	number, _ := strconv.Atoi(c.Labels[compose.ContainerNumberLabel])
+	return number
+}
+
+// without returns list items not listed in exclude
+func without(list []string, exclude ...string) []string {
+	var l []string
+	for _, s := range list {
+		if !utils.StringContains(exclude, s) {
+			l = append(l, s)
+		}
+	}
+	return l
+}
+
+func stringSliceEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
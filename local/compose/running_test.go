@@ -0,0 +1,148 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	volume_api "github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-connections/nat"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestProjectFromRunningContainers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	web1 := testContainer("web", "123")
+	web1.Labels[compose.ContainerNumberLabel] = "1"
+	web2 := testContainer("web", "456")
+	web2.Labels[compose.ContainerNumberLabel] = "2"
+	api.EXPECT().ContainerList(ctx, apitypes.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(testProject), oneOffFilter(false)),
+		All:     true,
+	}).Return([]apitypes.Container{web2, web1}, nil)
+	api.EXPECT().NetworkList(ctx, apitypes.NetworkListOptions{Filters: filters.NewArgs(projectFilter(testProject))}).
+		Return([]apitypes.NetworkResource{{
+			Name:   "testProject_default",
+			Driver: "bridge",
+			Labels: map[string]string{compose.ProjectLabel: testProject, compose.NetworkLabel: "default"},
+		}}, nil)
+	api.EXPECT().VolumeList(ctx, filters.NewArgs(projectFilter(testProject))).Return(volume_api.VolumeListOKBody{
+		Volumes: []*apitypes.Volume{{
+			Name:   "testProject_data",
+			Driver: "local",
+			Labels: map[string]string{compose.ProjectLabel: testProject, compose.VolumeLabel: "data"},
+		}},
+	}, nil)
+
+	labels := containerLabels("web")
+	labels[compose.ContainerNumberLabel] = "1"
+	labels["org.opencontainers.image.title"] = "nginx"
+	labels["tier"] = "front"
+	api.EXPECT().ContainerInspect(ctx, "123").Return(apitypes.ContainerJSON{
+		ContainerJSONBase: &apitypes.ContainerJSONBase{
+			ID:    "1234567890abcdef",
+			Name:  "/testProject_web_1",
+			Image: "sha256:nginx",
+			HostConfig: &container.HostConfig{
+				NetworkMode:   "testProject_default",
+				RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+				PortBindings: nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostPort: "8081"}, {HostPort: "8080"}},
+					"80/udp": []nat.PortBinding{{HostPort: "8080"}},
+				},
+			},
+		},
+		Config: &container.Config{
+			Image:  "nginx:alpine",
+			Cmd:    []string{"nginx", "-g", "daemon off;"},
+			Env:    []string{"PATH=/usr/bin", "MODE=production"},
+			Labels: labels,
+		},
+		Mounts: []apitypes.MountPoint{
+			{Type: mount.TypeVolume, Name: "testProject_data", Destination: "/data", RW: true},
+			{Type: mount.TypeVolume, Name: "shared_cache", Destination: "/cache", RW: true},
+			{Type: mount.TypeVolume, Name: "0d1cbe0e6c3b6aa8f3e6a4b1c9c7d9b1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7", Destination: "/tmp", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/nginx", Destination: "/etc/nginx"},
+		},
+		NetworkSettings: &apitypes.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"testProject_default": {Aliases: []string{"testProject_web_1", "web", "1234567890ab", "frontend"}},
+			},
+		},
+	}, nil)
+	api.EXPECT().ImageInspectWithRaw(ctx, "sha256:nginx").Return(apitypes.ImageInspect{
+		Config: &container.Config{
+			Cmd:    []string{"nginx", "-g", "daemon off;"},
+			Env:    []string{"PATH=/usr/bin"},
+			Labels: map[string]string{"org.opencontainers.image.title": "nginx"},
+		},
+	}, nil, nil)
+
+	project, err := tested.projectFromRunningContainers(ctx, testProject, nil)
+	assert.NilError(t, err)
+
+	mode := "production"
+	assert.DeepEqual(t, project.Services, types.Services{
+		{
+			Name:        "web",
+			Image:       "nginx:alpine",
+			Scale:       2,
+			Restart:     "on-failure:3",
+			Labels:      types.Labels{"tier": "front"},
+			Environment: types.MappingWithEquals{"MODE": &mode},
+			Ports: []types.ServicePortConfig{
+				{Mode: "ingress", Target: 80, Published: 8080, Protocol: "tcp"},
+				{Mode: "ingress", Target: 80, Published: 8080, Protocol: "udp"},
+				{Mode: "ingress", Target: 80, Published: 8081, Protocol: "tcp"},
+			},
+			Volumes: []types.ServiceVolumeConfig{
+				{Type: "volume", Source: "shared_cache", Target: "/cache"},
+				{Type: "volume", Source: "data", Target: "/data"},
+				{Type: "bind", Source: "/etc/nginx", Target: "/etc/nginx", ReadOnly: true},
+				{Type: "volume", Target: "/tmp"},
+			},
+			Networks: map[string]*types.ServiceNetworkConfig{
+				"default": {Aliases: []string{"frontend"}},
+			},
+		},
+	})
+	assert.DeepEqual(t, project.Networks, types.Networks{
+		"default": {Name: "testProject_default", Driver: "bridge"},
+	})
+	assert.DeepEqual(t, project.Volumes, types.Volumes{
+		"data":         {Name: "testProject_data", Driver: "local"},
+		"shared_cache": {Name: "shared_cache", External: types.External{External: true}},
+	})
+	assert.Equal(t, project.WorkingDir, labels[compose.WorkingDirLabel])
+	assert.DeepEqual(t, project.ComposeFiles, []string{labels[compose.ConfigFilesLabel]})
+}
@@ -0,0 +1,82 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"sort"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
+)
+
+// unsupportedServiceOptions are service attributes the engine has no container equivalent for, keyed by
+// compose-spec attribute path. Such attributes are ignored, with a warning when they are set. `device_cgroup_rules`
+// is dropped by compose-go loader, and `storage_opt` and `gpus` aren't defined by compose-spec schema yet, so none of
+// them can be detected here.
+var unsupportedServiceOptions = map[string]func(s types.ServiceConfig) bool{
+	"credential_spec.config": func(s types.ServiceConfig) bool {
+		return s.CredentialSpec != nil && s.CredentialSpec.Config != ""
+	},
+	"deploy.endpoint_mode": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.EndpointMode != ""
+	},
+	"deploy.labels": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && len(s.Deploy.Labels) > 0
+	},
+	"deploy.mode": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.Mode != "" && s.Deploy.Mode != "replicated"
+	},
+	"deploy.placement": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && (len(s.Deploy.Placement.Constraints) > 0 || len(s.Deploy.Placement.Preferences) > 0 || s.Deploy.Placement.MaxReplicas != 0)
+	},
+	"deploy.resources.reservations.cpus": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.Resources.Reservations != nil && s.Deploy.Resources.Reservations.NanoCPUs != ""
+	},
+	"deploy.resources.reservations.generic_resources": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.Resources.Reservations != nil && len(s.Deploy.Resources.Reservations.GenericResources) > 0
+	},
+	"deploy.restart_policy.delay": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.RestartPolicy != nil && s.Deploy.RestartPolicy.Delay != nil
+	},
+	"deploy.restart_policy.window": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.RestartPolicy != nil && s.Deploy.RestartPolicy.Window != nil
+	},
+	"deploy.rollback_config": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.RollbackConfig != nil
+	},
+	"deploy.update_config": func(s types.ServiceConfig) bool {
+		return s.Deploy != nil && s.Deploy.UpdateConfig != nil
+	},
+}
+
+// getUnsupportedServiceOptions returns the unsupported attributes set by service, sorted
+func getUnsupportedServiceOptions(service types.ServiceConfig) []string {
+	var unsupported []string
+	for option, isSet := range unsupportedServiceOptions {
+		if isSet(service) {
+			unsupported = append(unsupported, option)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+func warnUnsupportedServiceOptions(service types.ServiceConfig) {
+	for _, option := range getUnsupportedServiceOptions(service) {
+		logrus.Warnf("service %q: %s is not supported and will be ignored", service.Name, option)
+	}
+}
@@ -0,0 +1,567 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/schema"
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+// created is the container configuration returned by getCreateOptions
+type created struct {
+	config  *container.Config
+	host    *container.HostConfig
+	network *network.NetworkingConfig
+}
+
+// createdServiceOption sets a service attribute, and checks its value reaches the container configuration
+type createdServiceOption struct {
+	set   func(s *types.ServiceConfig)
+	check func(t *testing.T, c created)
+}
+
+// createdServiceOptions are the service attributes applied to containers by getCreateOptions. `uts` is also applied,
+// but isn't part of compose-spec schema yet.
+var createdServiceOptions = map[string]createdServiceOption{
+	"blkio_config": {
+		set:   func(s *types.ServiceConfig) { s.BlkioConfig = &types.BlkioConfig{Weight: 300} },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.BlkioWeight, uint16(300)) },
+	},
+	"cap_add": {
+		set:   func(s *types.ServiceConfig) { s.CapAdd = []string{"NET_ADMIN"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, []string(c.host.CapAdd), []string{"NET_ADMIN"}) },
+	},
+	"cap_drop": {
+		set:   func(s *types.ServiceConfig) { s.CapDrop = []string{"SYS_ADMIN"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, []string(c.host.CapDrop), []string{"SYS_ADMIN"}) },
+	},
+	"cgroup_parent": {
+		set:   func(s *types.ServiceConfig) { s.CgroupParent = "m-executor" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CgroupParent, "m-executor") },
+	},
+	"command": {
+		set:   func(s *types.ServiceConfig) { s.Command = types.ShellCommand{"echo", "hi"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, []string(c.config.Cmd), []string{"echo", "hi"}) },
+	},
+	"configs": {
+		set:   func(s *types.ServiceConfig) { s.Configs = []types.ServiceConfigObjConfig{{Source: "config"}} },
+		check: func(t *testing.T, c created) { assert.Check(t, hasMount(c.host, "/config")) },
+	},
+	"cpu_count": {
+		set:   func(s *types.ServiceConfig) { s.CPUCount = 2 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CPUCount, int64(2)) },
+	},
+	"cpu_percent": {
+		set:   func(s *types.ServiceConfig) { s.CPUPercent = 50 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CPUPercent, int64(50)) },
+	},
+	"cpu_period": {
+		set:   func(s *types.ServiceConfig) { s.CPUPeriod = 100000 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CPUPeriod, int64(100000)) },
+	},
+	"cpu_quota": {
+		set:   func(s *types.ServiceConfig) { s.CPUQuota = 50000 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CPUQuota, int64(50000)) },
+	},
+	"cpu_rt_period": {
+		set: func(s *types.ServiceConfig) { s.CPURTPeriod = 1000000 },
+		check: func(t *testing.T, c created) {
+			assert.Equal(t, c.host.CPURealtimePeriod, int64(1000000))
+		},
+	},
+	"cpu_rt_runtime": {
+		set: func(s *types.ServiceConfig) { s.CPURTRuntime = 950000 },
+		check: func(t *testing.T, c created) {
+			assert.Equal(t, c.host.CPURealtimeRuntime, int64(950000))
+		},
+	},
+	"cpu_shares": {
+		set:   func(s *types.ServiceConfig) { s.CPUShares = 512 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CPUShares, int64(512)) },
+	},
+	"cpus": {
+		set:   func(s *types.ServiceConfig) { s.CPUS = 1.5 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.NanoCPUs, int64(1500000000)) },
+	},
+	"cpuset": {
+		set:   func(s *types.ServiceConfig) { s.CPUSet = "0-1" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.CpusetCpus, "0-1") },
+	},
+	"credential_spec.file": {
+		set: func(s *types.ServiceConfig) { s.CredentialSpec = &types.CredentialSpecConfig{File: "spec.json"} },
+		check: func(t *testing.T, c created) {
+			assert.Check(t, is.Contains(c.host.SecurityOpt, "credentialspec=file://spec.json"))
+		},
+	},
+	"credential_spec.registry": {
+		set: func(s *types.ServiceConfig) { s.CredentialSpec = &types.CredentialSpecConfig{Registry: "spec"} },
+		check: func(t *testing.T, c created) {
+			assert.Check(t, is.Contains(c.host.SecurityOpt, "credentialspec=registry://spec"))
+		},
+	},
+	"deploy.resources.limits.cpus": {
+		set: func(s *types.ServiceConfig) {
+			s.Deploy = &types.DeployConfig{Resources: types.Resources{Limits: &types.Resource{NanoCPUs: "0.5"}}}
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.NanoCPUs, int64(500000000)) },
+	},
+	"deploy.resources.limits.memory": {
+		set: func(s *types.ServiceConfig) {
+			s.Deploy = &types.DeployConfig{Resources: types.Resources{Limits: &types.Resource{MemoryBytes: 1024}}}
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Memory, int64(1024)) },
+	},
+	"deploy.resources.reservations.devices": {
+		set: func(s *types.ServiceConfig) {
+			s.Deploy = &types.DeployConfig{Resources: types.Resources{Reservations: &types.Resource{
+				Devices: []types.DeviceRequest{{Capabilities: []string{"gpu"}, Count: 1}},
+			}}}
+		},
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.DeviceRequests, []container.DeviceRequest{{Capabilities: [][]string{{"gpu"}}, Count: 1}})
+		},
+	},
+	"deploy.resources.reservations.memory": {
+		set: func(s *types.ServiceConfig) {
+			s.Deploy = &types.DeployConfig{Resources: types.Resources{Reservations: &types.Resource{MemoryBytes: 1024}}}
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.MemoryReservation, int64(1024)) },
+	},
+	"deploy.restart_policy.condition": {
+		set: func(s *types.ServiceConfig) {
+			s.Deploy = &types.DeployConfig{RestartPolicy: &types.RestartPolicy{Condition: "on-failure"}}
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.RestartPolicy.Name, "on-failure") },
+	},
+	"deploy.restart_policy.max_attempts": {
+		set: func(s *types.ServiceConfig) {
+			attempts := uint64(3)
+			s.Deploy = &types.DeployConfig{RestartPolicy: &types.RestartPolicy{Condition: "on-failure", MaxAttempts: &attempts}}
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.RestartPolicy.MaximumRetryCount, 3) },
+	},
+	"devices": {
+		set: func(s *types.ServiceConfig) { s.Devices = []string{"/dev/sda:/dev/xvda:rwm"} },
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.Devices, []container.DeviceMapping{{PathOnHost: "/dev/sda", PathInContainer: "/dev/xvda", CgroupPermissions: "rwm"}})
+		},
+	},
+	"dns": {
+		set:   func(s *types.ServiceConfig) { s.DNS = types.StringList{"8.8.8.8"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.DNS, []string{"8.8.8.8"}) },
+	},
+	"dns_opt": {
+		set:   func(s *types.ServiceConfig) { s.DNSOpts = []string{"use-vc"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.DNSOptions, []string{"use-vc"}) },
+	},
+	"dns_search": {
+		set:   func(s *types.ServiceConfig) { s.DNSSearch = types.StringList{"example.com"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.DNSSearch, []string{"example.com"}) },
+	},
+	"domainname": {
+		set:   func(s *types.ServiceConfig) { s.DomainName = "example.com" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.Domainname, "example.com") },
+	},
+	"entrypoint": {
+		set: func(s *types.ServiceConfig) { s.Entrypoint = types.ShellCommand{"/entrypoint.sh"} },
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, []string(c.config.Entrypoint), []string{"/entrypoint.sh"})
+		},
+	},
+	"environment": {
+		set: func(s *types.ServiceConfig) {
+			value := "bar"
+			s.Environment = types.MappingWithEquals{"FOO": &value}
+		},
+		check: func(t *testing.T, c created) { assert.Check(t, is.Contains(c.config.Env, "FOO=bar")) },
+	},
+	"expose": {
+		set:   func(s *types.ServiceConfig) { s.Expose = types.StringOrNumberList{"80"} },
+		check: func(t *testing.T, c created) { assert.Check(t, is.Contains(c.config.ExposedPorts, nat.Port("80/tcp"))) },
+	},
+	"external_links": {
+		set:   func(s *types.ServiceConfig) { s.ExternalLinks = []string{"db:database"} },
+		check: func(t *testing.T, c created) { assert.Check(t, is.Contains(c.host.Links, "db:database")) },
+	},
+	"extra_hosts": {
+		set: func(s *types.ServiceConfig) { s.ExtraHosts = types.HostsList{"somehost:162.242.195.82"} },
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.ExtraHosts, []string{"somehost:162.242.195.82"})
+		},
+	},
+	"group_add": {
+		set:   func(s *types.ServiceConfig) { s.GroupAdd = []string{"mail"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.GroupAdd, []string{"mail"}) },
+	},
+	"healthcheck": {
+		set: func(s *types.ServiceConfig) {
+			s.HealthCheck = &types.HealthCheckConfig{Test: types.HealthCheckTest{"CMD", "true"}}
+		},
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.config.Healthcheck.Test, []string{"CMD", "true"})
+		},
+	},
+	"hostname": {
+		set:   func(s *types.ServiceConfig) { s.Hostname = "myhost" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.Hostname, "myhost") },
+	},
+	"image": {
+		set:   func(s *types.ServiceConfig) { s.Image = "nginx" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.Image, "nginx") },
+	},
+	"init": {
+		set: func(s *types.ServiceConfig) {
+			init := true
+			s.Init = &init
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, *c.host.Init, true) },
+	},
+	"ipc": {
+		set:   func(s *types.ServiceConfig) { s.Ipc = "host" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.IpcMode, container.IpcMode("host")) },
+	},
+	"isolation": {
+		set:   func(s *types.ServiceConfig) { s.Isolation = "process" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Isolation, container.Isolation("process")) },
+	},
+	"labels": {
+		set:   func(s *types.ServiceConfig) { s.Labels = types.Labels{"foo": "bar"} },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.Labels["foo"], "bar") },
+	},
+	"links": {
+		set: func(s *types.ServiceConfig) { s.Links = []string{"db:database"} },
+		check: func(t *testing.T, c created) {
+			assert.Check(t, is.Contains(c.host.Links, "testProject_db_1:database"))
+		},
+	},
+	"logging": {
+		set:   func(s *types.ServiceConfig) { s.Logging = &types.LoggingConfig{Driver: "syslog"} },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.LogConfig.Type, "syslog") },
+	},
+	"mac_address": {
+		set:   func(s *types.ServiceConfig) { s.MacAddress = "02:42:ac:11:65:43" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.MacAddress, "02:42:ac:11:65:43") },
+	},
+	"mem_limit": {
+		set:   func(s *types.ServiceConfig) { s.MemLimit = 1024 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Memory, int64(1024)) },
+	},
+	"mem_reservation": {
+		set:   func(s *types.ServiceConfig) { s.MemReservation = 1024 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.MemoryReservation, int64(1024)) },
+	},
+	"mem_swappiness": {
+		set:   func(s *types.ServiceConfig) { s.MemSwappiness = 60 },
+		check: func(t *testing.T, c created) { assert.Equal(t, *c.host.MemorySwappiness, int64(60)) },
+	},
+	"memswap_limit": {
+		set:   func(s *types.ServiceConfig) { s.MemSwapLimit = 2048 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.MemorySwap, int64(2048)) },
+	},
+	"network_mode": {
+		set:   func(s *types.ServiceConfig) { s.NetworkMode = "host" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.NetworkMode, container.NetworkMode("host")) },
+	},
+	"networks": {
+		set: func(s *types.ServiceConfig) {
+			s.Networks = map[string]*types.ServiceNetworkConfig{"front": {Aliases: []string{"web"}}}
+		},
+		check: func(t *testing.T, c created) {
+			assert.Check(t, is.Contains(c.network.EndpointsConfig["testProject_front"].Aliases, "web"))
+		},
+	},
+	"oom_kill_disable": {
+		set:   func(s *types.ServiceConfig) { s.OomKillDisable = true },
+		check: func(t *testing.T, c created) { assert.Equal(t, *c.host.OomKillDisable, true) },
+	},
+	"oom_score_adj": {
+		set:   func(s *types.ServiceConfig) { s.OomScoreAdj = 500 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.OomScoreAdj, 500) },
+	},
+	"pid": {
+		set:   func(s *types.ServiceConfig) { s.Pid = "host" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.PidMode, container.PidMode("host")) },
+	},
+	"pids_limit": {
+		set:   func(s *types.ServiceConfig) { s.PidsLimit = 100 },
+		check: func(t *testing.T, c created) { assert.Equal(t, *c.host.PidsLimit, int64(100)) },
+	},
+	"ports": {
+		set: func(s *types.ServiceConfig) {
+			s.Ports = []types.ServicePortConfig{{Target: 80, Published: 8080, Protocol: "tcp"}}
+		},
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.PortBindings["80/tcp"], []nat.PortBinding{{HostPort: "8080"}})
+		},
+	},
+	"privileged": {
+		set:   func(s *types.ServiceConfig) { s.Privileged = true },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Privileged, true) },
+	},
+	"read_only": {
+		set:   func(s *types.ServiceConfig) { s.ReadOnly = true },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.ReadonlyRootfs, true) },
+	},
+	"restart": {
+		set:   func(s *types.ServiceConfig) { s.Restart = "always" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.RestartPolicy.Name, "always") },
+	},
+	"runtime": {
+		set:   func(s *types.ServiceConfig) { s.Runtime = "runc" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Runtime, "runc") },
+	},
+	"secrets": {
+		set:   func(s *types.ServiceConfig) { s.Secrets = []types.ServiceSecretConfig{{Source: "secret"}} },
+		check: func(t *testing.T, c created) { assert.Check(t, hasMount(c.host, "/run/secrets/secret")) },
+	},
+	"security_opt": {
+		set:   func(s *types.ServiceConfig) { s.SecurityOpt = []string{"label:disable"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.SecurityOpt, []string{"label:disable"}) },
+	},
+	"shm_size": {
+		set:   func(s *types.ServiceConfig) { s.ShmSize = 64 },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.ShmSize, int64(64)) },
+	},
+	"stdin_open": {
+		set:   func(s *types.ServiceConfig) { s.StdinOpen = true },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.OpenStdin, true) },
+	},
+	"stop_grace_period": {
+		set: func(s *types.ServiceConfig) {
+			period := types.Duration(10 * time.Second)
+			s.StopGracePeriod = &period
+		},
+		check: func(t *testing.T, c created) { assert.Equal(t, *c.config.StopTimeout, 10) },
+	},
+	"stop_signal": {
+		set:   func(s *types.ServiceConfig) { s.StopSignal = "SIGUSR1" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.StopSignal, "SIGUSR1") },
+	},
+	"sysctls": {
+		set: func(s *types.ServiceConfig) { s.Sysctls = types.Mapping{"net.core.somaxconn": "1024"} },
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.Sysctls, map[string]string{"net.core.somaxconn": "1024"})
+		},
+	},
+	"tmpfs": {
+		set:   func(s *types.ServiceConfig) { s.Tmpfs = types.StringList{"/run:size=64m"} },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.Tmpfs["/run"], "size=64m") },
+	},
+	"tty": {
+		set:   func(s *types.ServiceConfig) { s.Tty = true },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.Tty, true) },
+	},
+	"ulimits": {
+		set: func(s *types.ServiceConfig) {
+			s.Ulimits = map[string]*types.UlimitsConfig{"nofile": {Soft: 1024, Hard: 2048}}
+		},
+		check: func(t *testing.T, c created) {
+			assert.DeepEqual(t, c.host.Ulimits, []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}})
+		},
+	},
+	"user": {
+		set:   func(s *types.ServiceConfig) { s.User = "1000" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.User, "1000") },
+	},
+	"userns_mode": {
+		set:   func(s *types.ServiceConfig) { s.UserNSMode = "host" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.host.UsernsMode, container.UsernsMode("host")) },
+	},
+	"volumes": {
+		set: func(s *types.ServiceConfig) {
+			s.Volumes = []types.ServiceVolumeConfig{{Type: types.VolumeTypeBind, Source: "/data", Target: "/data"}}
+		},
+		check: func(t *testing.T, c created) { assert.Check(t, hasMount(c.host, "/data")) },
+	},
+	"volumes_from": {
+		set:   func(s *types.ServiceConfig) { s.VolumesFrom = []string{"other"} },
+		check: func(t *testing.T, c created) { assert.DeepEqual(t, c.host.VolumesFrom, []string{"other"}) },
+	},
+	"working_dir": {
+		set:   func(s *types.ServiceConfig) { s.WorkingDir = "/app" },
+		check: func(t *testing.T, c created) { assert.Equal(t, c.config.WorkingDir, "/app") },
+	},
+}
+
+// projectServiceOptions are the service attributes applied by the loader, or while converging the project, rather than
+// to container configuration
+var projectServiceOptions = []string{
+	"build", "container_name", "depends_on", "deploy.replicas", "env_file", "extends", "platform", "profiles",
+	"pull_policy", "scale",
+}
+
+// notLoadedServiceOptions are defined by compose-spec but dropped by compose-go loader, so they can't be applied
+// nor detected
+var notLoadedServiceOptions = []string{
+	"device_cgroup_rules",
+}
+
+// notInSchemaServiceOptions are engine options compose-spec schema doesn't define yet. Compose files setting them
+// fail validation, so they can't be applied nor reported as unsupported.
+var notInSchemaServiceOptions = []string{
+	"gpus", "storage_opt",
+}
+
+// expandedServiceOptions are the service attributes checked attribute by attribute
+var expandedServiceOptions = map[string]bool{
+	"credential_spec":               true,
+	"deploy":                        true,
+	"deploy.resources":              true,
+	"deploy.resources.limits":       true,
+	"deploy.resources.reservations": true,
+	"deploy.restart_policy":         true,
+}
+
+type schemaDefinition struct {
+	Ref        string                      `json:"$ref"`
+	Properties map[string]schemaDefinition `json:"properties"`
+}
+
+type composeSchema struct {
+	Definitions map[string]schemaDefinition `json:"definitions"`
+}
+
+func schemaServiceOptions(t *testing.T) []string {
+	var s composeSchema
+	assert.NilError(t, json.Unmarshal([]byte(schema.Schema), &s))
+
+	var options []string
+	var walk func(prefix string, def schemaDefinition)
+	walk = func(prefix string, def schemaDefinition) {
+		for name, property := range def.Properties {
+			path := prefix + name
+			if property.Ref == "#/definitions/deployment" {
+				property = s.Definitions["deployment"]
+			}
+			if expandedServiceOptions[path] {
+				walk(path+".", property)
+				continue
+			}
+			options = append(options, path)
+		}
+	}
+	walk("", s.Definitions["service"])
+	sort.Strings(options)
+	return options
+}
+
+func TestServiceOptionsConformance(t *testing.T) {
+	known := map[string]bool{}
+	for option := range createdServiceOptions {
+		known[option] = true
+	}
+	for _, option := range projectServiceOptions {
+		assert.Check(t, !known[option], "%s is both created and applied to project", option)
+		known[option] = true
+	}
+	for _, option := range notLoadedServiceOptions {
+		known[option] = true
+	}
+	for _, option := range notLoadedServiceOptions {
+		known[option] = true
+	}
+	for option := range unsupportedServiceOptions {
+		assert.Check(t, !known[option], "%s is both mapped and unsupported", option)
+		known[option] = true
+	}
+
+	schemaOptions := map[string]bool{}
+	for _, option := range schemaServiceOptions(t) {
+		schemaOptions[option] = true
+		assert.Check(t, known[option], "compose-spec service attribute %s is neither mapped nor reported as unsupported", option)
+	}
+	for option := range known {
+		assert.Check(t, schemaOptions[option], "%s is not a compose-spec service attribute", option)
+	}
+	for _, option := range notInSchemaServiceOptions {
+		assert.Check(t, !schemaOptions[option], "compose-spec now defines %s, map it or report it as unsupported", option)
+	}
+}
+
+func TestCreatedServiceOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.WithValue(context.Background(), ContainersKey{}, NewContainersState(Containers{}))
+	api.EXPECT().ImageInspectWithRaw(ctx, gomock.Any()).Return(moby.ImageInspect{}, nil, nil).AnyTimes()
+	api.EXPECT().ContainerList(ctx, gomock.Any()).Return([]moby.Container{{Names: []string{"/testProject_db_1"}}}, nil).AnyTimes()
+
+	for option, o := range createdServiceOptions {
+		option, o := option, o
+		t.Run(option, func(t *testing.T) {
+			service := types.ServiceConfig{Name: "test", Image: "busybox"}
+			o.set(&service)
+			project := &types.Project{
+				Name:     testProject,
+				Services: types.Services{service},
+				Networks: types.Networks{"front": {Name: "testProject_front"}},
+				Configs:  map[string]types.ConfigObjConfig{"config": {File: "/config.txt"}},
+				Secrets:  map[string]types.SecretConfig{"secret": {File: "/secret.txt"}},
+			}
+			config, hostConfig, networkConfig, err := tested.getCreateOptions(ctx, project, service, 1, nil, false)
+			assert.NilError(t, err)
+			o.check(t, created{config: config, host: hostConfig, network: networkConfig})
+		})
+	}
+}
+
+func hasMount(hostConfig *container.HostConfig, target string) bool {
+	for _, m := range hostConfig.Mounts {
+		if m.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGetUnsupportedServiceOptions(t *testing.T) {
+	assert.Check(t, len(getUnsupportedServiceOptions(types.ServiceConfig{Name: "test"})) == 0)
+
+	service := types.ServiceConfig{
+		Name:           "test",
+		CredentialSpec: &types.CredentialSpecConfig{Config: "spec"},
+		Deploy: &types.DeployConfig{
+			Mode:         "global",
+			UpdateConfig: &types.UpdateConfig{},
+			Placement:    types.Placement{Constraints: []string{"node.role==manager"}},
+		},
+	}
+	assert.DeepEqual(t, getUnsupportedServiceOptions(service), []string{
+		"credential_spec.config",
+		"deploy.mode",
+		"deploy.placement",
+		"deploy.update_config",
+	})
+}
@@ -0,0 +1,379 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	volume_api "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/progress"
+	"github.com/docker/compose-cli/utils"
+	"github.com/docker/compose-cli/utils/prompt"
+)
+
+const (
+	// volumeHelperImage is used to create short-lived containers giving access to volumes content
+	volumeHelperImage = "busybox:latest"
+	// volumeHelperMountPoint is the path volume is mounted to in helper container
+	volumeHelperMountPoint = "/volume"
+	// volumeBackupManifest is the file describing volumes in a backup directory
+	volumeBackupManifest = "manifest.json"
+)
+
+type backupManifest struct {
+	Project string         `json:"project"`
+	Created time.Time      `json:"created"`
+	Volumes []volumeBackup `json:"volumes"`
+}
+
+type volumeBackup struct {
+	Name       string            `json:"name"`
+	Volume     string            `json:"volume"`
+	Driver     string            `json:"driver"`
+	DriverOpts map[string]string `json:"driverOpts,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Archive    string            `json:"archive"`
+}
+
+func (s *composeService) BackupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return s.backupVolumes(ctx, projectName, options)
+	})
+}
+
+func (s *composeService) backupVolumes(ctx context.Context, projectName string, options compose.BackupVolumesOptions) error {
+	volumes, err := s.getProjectVolumes(ctx, projectName, options.Volumes)
+	if err != nil {
+		return err
+	}
+	if len(volumes) == 0 {
+		return fmt.Errorf("no volume to backup for project %q", projectName)
+	}
+	if err := os.MkdirAll(options.Output, 0755); err != nil {
+		return err
+	}
+	if err := s.ensureVolumeHelperImage(ctx); err != nil {
+		return err
+	}
+
+	manifest := backupManifest{
+		Project: projectName,
+		Created: time.Now().UTC(),
+		Volumes: make([]volumeBackup, len(volumes)),
+	}
+	w := progress.ContextWriter(ctx)
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, v := range volumes {
+		i, volume := i, v
+		eg.Go(func() error {
+			eventName := fmt.Sprintf("Volume %q", volume.Name)
+			w.Event(progress.NewEvent(eventName, progress.Working, "Backing up"))
+			archive := volume.Name + ".tar.gz"
+			if err := s.backupVolume(ctx, volume.Name, filepath.Join(options.Output, archive)); err != nil {
+				w.Event(progress.ErrorEvent(eventName))
+				return err
+			}
+			w.Event(progress.NewEvent(eventName, progress.Done, "Backed up"))
+			manifest.Volumes[i] = volumeBackup{
+				Name:       volume.Labels[compose.VolumeLabel],
+				Volume:     volume.Name,
+				Driver:     volume.Driver,
+				DriverOpts: volume.Options,
+				Labels:     volume.Labels,
+				Archive:    archive,
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(options.Output, volumeBackupManifest), b, 0644)
+}
+
+// backupVolume writes volume content as a tar.gz archive to file
+func (s *composeService) backupVolume(ctx context.Context, volume string, file string) error {
+	helper, err := s.createVolumeHelper(ctx, volume)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(ctx, helper)
+
+	content, _, err := s.apiClient.CopyFromContainer(ctx, helper, volumeHelperMountPoint)
+	if err != nil {
+		return err
+	}
+	defer content.Close() //nolint:errcheck
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	gz := gzip.NewWriter(f)
+	if _, err := io.Copy(gz, content); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (s *composeService) RestoreVolumes(ctx context.Context, projectName string, options compose.RestoreVolumesOptions) error {
+	volumes, err := readVolumesBackup(projectName, options)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	var names []string
+	for _, volume := range volumes {
+		_, err := s.apiClient.VolumeInspect(ctx, volume.Volume)
+		switch {
+		case err == nil:
+			existing[volume.Volume] = true
+			names = append(names, volume.Volume)
+		case !errdefs.IsNotFound(err):
+			return err
+		}
+	}
+	if len(names) > 0 {
+		msg := fmt.Sprintf("Going to replace volumes %s", strings.Join(names, ", "))
+		if options.Force {
+			fmt.Println(msg)
+		} else {
+			confirm, err := prompt.User{}.Confirm(msg, false)
+			if err != nil {
+				return err
+			}
+			if !confirm {
+				return nil
+			}
+		}
+	}
+
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return s.restoreVolumes(ctx, volumes, existing, options.Input)
+	})
+}
+
+// readVolumesBackup loads the backup manifest and checks archives for the volumes to be restored
+func readVolumesBackup(projectName string, options compose.RestoreVolumesOptions) ([]volumeBackup, error) {
+	b, err := ioutil.ReadFile(filepath.Join(options.Input, volumeBackupManifest))
+	if err != nil {
+		return nil, err
+	}
+	var manifest backupManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Project != projectName {
+		return nil, fmt.Errorf("backup in %s has been created for project %q", options.Input, manifest.Project)
+	}
+
+	var volumes []volumeBackup
+	for _, volume := range manifest.Volumes {
+		if len(options.Volumes) > 0 && !utils.StringContains(options.Volumes, volume.Name) {
+			continue
+		}
+		if err := checkVolumeArchive(filepath.Join(options.Input, volume.Archive)); err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// checkVolumeArchive reads archive file through to make sure it is a valid volume backup before any volume is removed
+func checkVolumeArchive(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("invalid volume archive %s: %w", file, err)
+	}
+	root := path.Base(volumeHelperMountPoint)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid volume archive %s: %w", file, err)
+		}
+		name := path.Clean(hdr.Name)
+		if name != root && !strings.HasPrefix(name, root+"/") {
+			return fmt.Errorf("invalid volume archive %s: unexpected entry %q", file, hdr.Name)
+		}
+		if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+			return fmt.Errorf("invalid volume archive %s: %w", file, err)
+		}
+	}
+}
+
+func (s *composeService) restoreVolumes(ctx context.Context, volumes []volumeBackup, existing map[string]bool, input string) error {
+	if err := s.ensureVolumeHelperImage(ctx); err != nil {
+		return err
+	}
+
+	w := progress.ContextWriter(ctx)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, v := range volumes {
+		volume := v
+		eg.Go(func() error {
+			eventName := fmt.Sprintf("Volume %q", volume.Volume)
+			w.Event(progress.NewEvent(eventName, progress.Working, "Restoring"))
+			if err := s.restoreVolume(ctx, volume, existing[volume.Volume], filepath.Join(input, volume.Archive)); err != nil {
+				w.Event(progress.ErrorEvent(eventName))
+				return err
+			}
+			w.Event(progress.NewEvent(eventName, progress.Done, "Restored"))
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// restoreVolume recreates volume with the same driver options and extracts archive file into it
+func (s *composeService) restoreVolume(ctx context.Context, volume volumeBackup, exists bool, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	if exists {
+		if err := s.apiClient.VolumeRemove(ctx, volume.Volume, false); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.apiClient.VolumeCreate(ctx, volume_api.VolumeCreateBody{
+		Name:       volume.Volume,
+		Driver:     volume.Driver,
+		DriverOpts: volume.DriverOpts,
+		Labels:     volume.Labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	helper, err := s.createVolumeHelper(ctx, volume.Volume)
+	if err != nil {
+		return err
+	}
+	defer s.removeVolumeHelper(ctx, helper)
+
+	// archive entries are relative to volume mount point parent directory
+	return s.apiClient.CopyToContainer(ctx, helper, "/", f, moby.CopyToContainerOptions{
+		CopyUIDGID: true,
+	})
+}
+
+func (s *composeService) getProjectVolumes(ctx context.Context, projectName string, selected []string) ([]*moby.Volume, error) {
+	list, err := s.apiClient.VolumeList(ctx, filters.NewArgs(projectFilter(projectName)))
+	if err != nil {
+		return nil, err
+	}
+	var volumes []*moby.Volume
+	for _, volume := range list.Volumes {
+		if len(selected) > 0 && !utils.StringContains(selected, volume.Labels[compose.VolumeLabel]) {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes, nil
+}
+
+func (s *composeService) ensureVolumeHelperImage(ctx context.Context) error {
+	_, _, err := s.apiClient.ImageInspectWithRaw(ctx, volumeHelperImage)
+	if err == nil {
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+	stream, err := s.apiClient.ImagePull(ctx, volumeHelperImage, moby.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close() //nolint:errcheck
+	_, err = io.Copy(ioutil.Discard, stream)
+	return err
+}
+
+// createVolumeHelper creates a container with volume mounted, which is never started but used to access volume content
+func (s *composeService) createVolumeHelper(ctx context.Context, volume string) (string, error) {
+	created, err := s.apiClient.ContainerCreate(ctx, &container.Config{
+		Image: volumeHelperImage,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeVolume,
+				Source: volume,
+				Target: volumeHelperMountPoint,
+			},
+		},
+	}, nil, nil, "")
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (s *composeService) removeVolumeHelper(ctx context.Context, id string) {
+	err := s.apiClient.ContainerRemove(ctx, id, moby.ContainerRemoveOptions{Force: true})
+	if err != nil {
+		logrus.Warnf("failed to remove volume helper container %s: %v", id, err)
+	}
+}
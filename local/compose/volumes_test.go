@@ -0,0 +1,105 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	volume_api "github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestBackupAndRestoreVolumes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	dir := t.TempDir()
+	labels := map[string]string{
+		compose.ProjectLabel: testProject,
+		compose.VolumeLabel:  "data",
+	}
+	driverOpts := map[string]string{"type": "tmpfs", "device": "tmpfs"}
+
+	ctx := context.Background()
+	api.EXPECT().VolumeList(ctx, filters.NewArgs(projectFilter(testProject))).Return(volume_api.VolumeListOKBody{
+		Volumes: []*apitypes.Volume{{Name: "testProject_data", Driver: "local", Options: driverOpts, Labels: labels}},
+	}, nil)
+	api.EXPECT().ImageInspectWithRaw(gomock.Any(), volumeHelperImage).Return(apitypes.ImageInspect{}, nil, nil).Times(2)
+	api.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").Return(container.ContainerCreateCreatedBody{ID: "helper"}, nil).Times(2)
+	api.EXPECT().CopyFromContainer(gomock.Any(), "helper", volumeHelperMountPoint).
+		Return(testArchive(t, "volume/", "volume/db.sql"), apitypes.ContainerPathStat{}, nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "helper", apitypes.ContainerRemoveOptions{Force: true}).Return(nil).Times(2)
+
+	err := tested.backupVolumes(ctx, testProject, compose.BackupVolumesOptions{Output: dir})
+	assert.NilError(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, volumeBackupManifest))
+	assert.NilError(t, err)
+	assert.Assert(t, len(b) > 0)
+
+	api.EXPECT().VolumeInspect(gomock.Any(), "testProject_data").Return(apitypes.Volume{Name: "testProject_data"}, nil)
+	api.EXPECT().VolumeRemove(gomock.Any(), "testProject_data", false).Return(nil)
+	api.EXPECT().VolumeCreate(gomock.Any(), volume_api.VolumeCreateBody{
+		Name:       "testProject_data",
+		Driver:     "local",
+		DriverOpts: driverOpts,
+		Labels:     labels,
+	}).Return(apitypes.Volume{}, nil)
+	api.EXPECT().CopyToContainer(gomock.Any(), "helper", "/", gomock.Any(), apitypes.CopyToContainerOptions{CopyUIDGID: true}).
+		DoAndReturn(func(ctx context.Context, container, path string, content io.Reader, options apitypes.CopyToContainerOptions) error {
+			gz, err := gzip.NewReader(content)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, archiveEntries(t, gz), []string{"volume/", "volume/db.sql"})
+			return nil
+		})
+
+	err = tested.RestoreVolumes(ctx, testProject, compose.RestoreVolumesOptions{Input: dir, Force: true})
+	assert.NilError(t, err)
+
+	err = tested.RestoreVolumes(ctx, "other", compose.RestoreVolumesOptions{Input: dir, Force: true})
+	assert.ErrorContains(t, err, `has been created for project "testProject"`)
+}
+
+func TestRestoreVolumesInvalidArchive(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	dir := t.TempDir()
+	manifest := `{"project": "testProject", "volumes": [{"name": "data", "volume": "testProject_data", "archive": "testProject_data.tar.gz"}]}`
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, volumeBackupManifest), []byte(manifest), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "testProject_data.tar.gz"), []byte("not an archive"), 0644))
+
+	// volume must not be inspected nor removed
+	err := tested.RestoreVolumes(context.Background(), testProject, compose.RestoreVolumesOptions{Input: dir, Force: true})
+	assert.ErrorContains(t, err, "invalid volume archive")
+}
@@ -0,0 +1,116 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	moby "github.com/docker/docker/api/types"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func (s *composeService) Wait(ctx context.Context, projectName string, options compose.WaitOptions) (int, error) {
+	// events are replayed from this point, so containers exiting while their state is collected are not missed
+	since := time.Now()
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, true, options.Services...)
+	if err != nil {
+		return 0, err
+	}
+	if len(containers) == 0 {
+		return 0, fmt.Errorf("no container found for project %q", projectName)
+	}
+
+	exitCode, completed := 0, 0
+	pending := map[string]bool{}
+	for _, c := range containers {
+		inspected, err := s.apiClient.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return 0, err
+		}
+		exited, code := containerExited(inspected)
+		if !exited {
+			if inspected.State != nil && (inspected.State.Running || inspected.State.Restarting) {
+				pending[c.ID] = true
+			}
+			continue
+		}
+		completed++
+		if code > exitCode {
+			exitCode = code
+		}
+	}
+	if len(pending) == 0 {
+		if completed == 0 {
+			return 0, fmt.Errorf("no container running for project %q", projectName)
+		}
+		return exitCode, nil
+	}
+	if !options.All {
+		// containers which completed before the wait started, i.e. init jobs, don't end the wait for running ones
+		exitCode = 0
+	}
+
+	watchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	err = s.Events(watchCtx, projectName, compose.EventsOptions{
+		Services: options.Services,
+		Since:    strconv.FormatInt(since.Unix(), 10),
+		Types:    []string{compose.EventDie},
+		Consumer: func(event compose.Event) error {
+			if !pending[event.Container] {
+				return nil
+			}
+			inspected, err := s.apiClient.ContainerInspect(watchCtx, event.Container)
+			if err != nil {
+				return err
+			}
+			exited, code := containerExited(inspected)
+			if !exited {
+				// container is restarting
+				return nil
+			}
+			delete(pending, event.Container)
+			if code > exitCode || !options.All {
+				exitCode = code
+			}
+			if !options.All || len(pending) == 0 {
+				stop()
+			}
+			return nil
+		},
+	})
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	if err != nil && !errors.Is(watchCtx.Err(), context.Canceled) {
+		return 0, err
+	}
+	return exitCode, nil
+}
+
+// containerExited tells if container has exited and will not be restarted, with its exit code
+func containerExited(container moby.ContainerJSON) (bool, int) {
+	if container.State == nil || container.State.Status != "exited" || container.State.Restarting {
+		return false, 0
+	}
+	return true, container.State.ExitCode
+}
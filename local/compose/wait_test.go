@@ -0,0 +1,156 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	apitypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/local/mocks"
+)
+
+func TestWaitAllCompleted(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, gomock.Any()).Return([]apitypes.Container{testContainer("service1", "123"), testContainer("service2", "456")}, nil)
+	api.EXPECT().ContainerInspect(ctx, "123").Return(exitedContainer("123", 0), nil)
+	api.EXPECT().ContainerInspect(ctx, "456").Return(exitedContainer("456", 2), nil)
+
+	exitCode, err := tested.Wait(ctx, testProject, compose.WaitOptions{
+		Services: []string{"service1", "service2"},
+		All:      true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 2)
+}
+
+func TestWaitForExit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, gomock.Any()).Return([]apitypes.Container{testContainer("service1", "123")}, nil)
+	api.EXPECT().ContainerInspect(ctx, "123").Return(runningContainer("123"), nil)
+	api.EXPECT().ContainerInspect(gomock.Any(), "123").Return(exitedContainer("123", 3), nil)
+	api.EXPECT().Events(gomock.Any(), gomock.Any()).DoAndReturn(dieEvents("123"))
+
+	exitCode, err := tested.Wait(ctx, testProject, compose.WaitOptions{
+		Services: []string{"service1"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 3)
+}
+
+func TestWaitIgnoresCompletedBeforeWait(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, gomock.Any()).Return([]apitypes.Container{testContainer("init", "123"), testContainer("service1", "456")}, nil)
+	api.EXPECT().ContainerInspect(ctx, "123").Return(exitedContainer("123", 4), nil)
+	api.EXPECT().ContainerInspect(ctx, "456").Return(runningContainer("456"), nil)
+	api.EXPECT().ContainerInspect(gomock.Any(), "456").Return(exitedContainer("456", 0), nil)
+	api.EXPECT().Events(gomock.Any(), gomock.Any()).DoAndReturn(dieEvents("456"))
+
+	exitCode, err := tested.Wait(ctx, testProject, compose.WaitOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 0)
+}
+
+func TestWaitAllReplicas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	api := mocks.NewMockAPIClient(mockCtrl)
+	tested.apiClient = api
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, gomock.Any()).Return([]apitypes.Container{testContainer("service1", "123"), testContainer("service1", "456")}, nil)
+	api.EXPECT().ContainerInspect(ctx, "123").Return(runningContainer("123"), nil)
+	api.EXPECT().ContainerInspect(ctx, "456").Return(runningContainer("456"), nil)
+	api.EXPECT().ContainerInspect(gomock.Any(), "123").Return(exitedContainer("123", 1), nil)
+	api.EXPECT().ContainerInspect(gomock.Any(), "456").Return(exitedContainer("456", 0), nil)
+	// events since listing are replayed, those of containers not being waited for are ignored
+	api.EXPECT().Events(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, options apitypes.EventsOptions) (<-chan events.Message, <-chan error) {
+			assert.Assert(t, options.Since != "")
+			return dieEvents("789", "123", "456")(ctx, options)
+		})
+
+	exitCode, err := tested.Wait(ctx, testProject, compose.WaitOptions{
+		Services: []string{"service1"},
+		All:      true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 1)
+}
+
+func dieEvents(ids ...string) func(ctx context.Context, options apitypes.EventsOptions) (<-chan events.Message, <-chan error) {
+	return func(ctx context.Context, options apitypes.EventsOptions) (<-chan events.Message, <-chan error) {
+		messages := make(chan events.Message)
+		errs := make(chan error)
+		go func() {
+			for _, id := range ids {
+				select {
+				case messages <- events.Message{
+					Type:   "container",
+					ID:     id,
+					Status: "die",
+					Actor:  events.Actor{ID: id, Attributes: map[string]string{compose.ServiceLabel: "service1"}},
+				}:
+				case <-ctx.Done():
+				}
+			}
+			// engine client reports cancellation on errors channel
+			<-ctx.Done()
+			errs <- ctx.Err()
+		}()
+		return messages, errs
+	}
+}
+
+func runningContainer(id string) apitypes.ContainerJSON {
+	container := exitedContainer(id, 0)
+	container.State = &apitypes.ContainerState{Status: "running", Running: true}
+	return container
+}
+
+func exitedContainer(id string, exitCode int) apitypes.ContainerJSON {
+	return apitypes.ContainerJSON{
+		ContainerJSONBase: &apitypes.ContainerJSONBase{
+			ID:         id,
+			Name:       "/" + id,
+			State:      &apitypes.ContainerState{Status: "exited", ExitCode: exitCode},
+			HostConfig: &container.HostConfig{},
+		},
+		Config: &container.Config{Labels: containerLabels("service1")},
+	}
+}
@@ -0,0 +1,145 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/system"
+	"github.com/pkg/errors"
+)
+
+// HasGlobPattern checks if a copy path holds a glob pattern
+func HasGlobPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// TrimArchiveDotPrefix names the entries of an archive of a directory content, as created by `tar -C dir .`,
+// relative to its "./" root entry
+func TrimArchiveDotPrefix(content io.ReadCloser) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		defer content.Close() //nolint:errcheck
+		tr := tar.NewReader(content)
+		tw := tar.NewWriter(w)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+			if hdr.Name != "./" {
+				hdr.Name = strings.TrimPrefix(hdr.Name, "./")
+			}
+			if hdr.Typeflag == tar.TypeLink {
+				hdr.Linkname = strings.TrimPrefix(hdr.Linkname, "./")
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+		}
+		w.CloseWithError(tw.Close()) //nolint:errcheck
+	}()
+	return r
+}
+
+// FilterArchive only keeps entries from a directory archive matching pattern, moving them to the root of the archive
+func FilterArchive(content io.ReadCloser, base string, pattern string) io.ReadCloser {
+	prefix := strings.TrimPrefix(base+"/", "/")
+	r, w := io.Pipe()
+	go func() {
+		defer content.Close() //nolint:errcheck
+		tr := tar.NewReader(content)
+		tw := tar.NewWriter(w)
+		matched := false
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+			name := strings.TrimPrefix(hdr.Name, prefix)
+			if name == "" || name == "./" {
+				continue
+			}
+			if ok, _ := path.Match(pattern, strings.SplitN(name, "/", 2)[0]); !ok {
+				continue
+			}
+			matched = true
+			hdr.Name = name
+			if hdr.Typeflag == tar.TypeLink {
+				hdr.Linkname = strings.TrimPrefix(hdr.Linkname, prefix)
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				w.CloseWithError(err) //nolint:errcheck
+				return
+			}
+		}
+		if !matched {
+			w.CloseWithError(errors.Errorf("no such file matching %q", pattern)) //nolint:errcheck
+			return
+		}
+		w.CloseWithError(tw.Close()) //nolint:errcheck
+	}()
+	return r
+}
+
+// SplitCopyArg splits a `compose cp` argument into service name and path. Service is empty for a local path.
+func SplitCopyArg(arg string) (container, path string) {
+	if system.IsAbs(arg) {
+		// Explicit local absolute path, e.g., `C:\foo` or `/foo`.
+		return "", arg
+	}
+
+	parts := strings.SplitN(arg, ":", 2)
+
+	if len(parts) == 1 || strings.HasPrefix(parts[0], ".") {
+		// Either there's no `:` in the arg
+		// OR it's an explicit local relative path like `./file:name.txt`.
+		return "", arg
+	}
+
+	return parts[0], parts[1]
+}
+
+// ResolveLocalPath gets the absolute path of a local copy path, preserving trailing separator or dot
+func ResolveLocalPath(localPath string) (absPath string, err error) {
+	if absPath, err = filepath.Abs(localPath); err != nil {
+		return
+	}
+	return archive.PreserveTrailingDotOrSeparator(absPath, localPath, filepath.Separator), nil
+}
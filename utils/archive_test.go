@@ -0,0 +1,84 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFilterArchive(t *testing.T) {
+	content := testArchive(t, "log/", "log/access.log", "log/error.log", "log/debug.txt", "log/old.log/", "log/old.log/archive")
+	filtered := FilterArchive(content, "log", "*.log")
+	assert.DeepEqual(t, archiveEntries(t, filtered), []string{"access.log", "error.log", "old.log/", "old.log/archive"})
+
+	content = testArchive(t, "log/", "log/debug.txt")
+	_, err := ioutil.ReadAll(FilterArchive(content, "log", "*.log"))
+	assert.Error(t, err, `no such file matching "*.log"`)
+}
+
+func TestTrimArchiveDotPrefix(t *testing.T) {
+	content := testArchive(t, "./", "./etc/", "./etc/hosts", "./app.log")
+	assert.DeepEqual(t, archiveEntries(t, TrimArchiveDotPrefix(content)), []string{"./", "etc/", "etc/hosts", "app.log"})
+
+	content = testArchive(t, "./", "./var/", "./app.log", "./error.log")
+	filtered := FilterArchive(TrimArchiveDotPrefix(content), "/", "*.log")
+	assert.DeepEqual(t, archiveEntries(t, filtered), []string{"app.log", "error.log"})
+}
+
+func TestSplitCopyArg(t *testing.T) {
+	service, path := SplitCopyArg("web:/var/log")
+	assert.Equal(t, service, "web")
+	assert.Equal(t, path, "/var/log")
+
+	service, path = SplitCopyArg("./file:name.txt")
+	assert.Equal(t, service, "")
+	assert.Equal(t, path, "./file:name.txt")
+}
+
+func testArchive(t *testing.T, names ...string) io.ReadCloser {
+	buf := bytes.Buffer{}
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		hdr := &tar.Header{Name: name, Mode: 0644, Typeflag: tar.TypeReg}
+		if name[len(name)-1] == '/' {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0755
+		}
+		assert.NilError(t, tw.WriteHeader(hdr))
+	}
+	assert.NilError(t, tw.Close())
+	return ioutil.NopCloser(&buf)
+}
+
+func archiveEntries(t *testing.T, content io.Reader) []string {
+	var entries []string
+	tr := tar.NewReader(content)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		assert.NilError(t, err)
+		entries = append(entries, hdr.Name)
+	}
+}
@@ -17,6 +17,13 @@
 package utils
 
 import (
+	"regexp"
+	"strings"
+	"time"
+
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/pkg/errors"
+
 	"github.com/docker/compose-cli/api/compose"
 )
 
@@ -46,6 +53,12 @@ func (a *allowListLogConsumer) Log(container, service, message string) {
 	}
 }
 
+func (a *allowListLogConsumer) Err(container, service, message string) {
+	if a.allowList[service] {
+		a.delegate.Err(container, service, message)
+	}
+}
+
 func (a *allowListLogConsumer) Status(container, message string) {
 	if a.allowList[container] {
 		a.delegate.Status(container, message)
@@ -57,3 +70,108 @@ func (a *allowListLogConsumer) Register(name string) {
 		a.delegate.Register(name)
 	}
 }
+
+// GrepLogConsumer filters log messages matching the pattern regular expression. With timestamps, the pattern is
+// matched against messages without their timestamp prefix.
+func GrepLogConsumer(consumer compose.LogConsumer, pattern string, timestamps bool) (compose.LogConsumer, error) {
+	if pattern == "" {
+		return consumer, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid grep pattern %q", pattern)
+	}
+	return &grepLogConsumer{
+		pattern:    re,
+		timestamps: timestamps,
+		delegate:   consumer,
+	}, nil
+}
+
+type grepLogConsumer struct {
+	pattern    *regexp.Regexp
+	timestamps bool
+	delegate   compose.LogConsumer
+}
+
+func (g *grepLogConsumer) match(message string) bool {
+	if g.timestamps {
+		if parts := strings.SplitN(message, " ", 2); len(parts) == 2 {
+			if _, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+				message = parts[1]
+			}
+		}
+	}
+	return g.pattern.MatchString(message)
+}
+
+func (g *grepLogConsumer) Log(container, service, message string) {
+	if g.match(message) {
+		g.delegate.Log(container, service, message)
+	}
+}
+
+func (g *grepLogConsumer) Err(container, service, message string) {
+	if g.match(message) {
+		g.delegate.Err(container, service, message)
+	}
+}
+
+func (g *grepLogConsumer) Status(container, message string) {
+	g.delegate.Status(container, message)
+}
+
+func (g *grepLogConsumer) Register(name string) {
+	g.delegate.Register(name)
+}
+
+// MultiLogConsumer duplicates log events to all consumers
+func MultiLogConsumer(consumers ...compose.LogConsumer) compose.LogConsumer {
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return multiLogConsumer(consumers)
+}
+
+type multiLogConsumer []compose.LogConsumer
+
+func (m multiLogConsumer) Log(container, service, message string) {
+	for _, c := range m {
+		c.Log(container, service, message)
+	}
+}
+
+func (m multiLogConsumer) Err(container, service, message string) {
+	for _, c := range m {
+		c.Err(container, service, message)
+	}
+}
+
+func (m multiLogConsumer) Status(container, message string) {
+	for _, c := range m {
+		c.Status(container, message)
+	}
+}
+
+func (m multiLogConsumer) Register(name string) {
+	for _, c := range m {
+		c.Register(name)
+	}
+}
+
+// ParseLogTime parses a log time boundary, either relative to now (i.e. `42m`) or absolute (RFC3339 or
+// unix timestamp). A zero time is returned for an empty value.
+func ParseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := timetypes.GetTimestamp(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec), nil
+}
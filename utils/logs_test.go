@@ -0,0 +1,86 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type testLogConsumer struct {
+	lines []string
+}
+
+func (l *testLogConsumer) Log(container, service, message string) {
+	l.lines = append(l.lines, message)
+}
+
+func (l *testLogConsumer) Err(container, service, message string) {
+	l.lines = append(l.lines, message)
+}
+
+func (l *testLogConsumer) Status(container, msg string) {}
+
+func (l *testLogConsumer) Register(container string) {}
+
+func TestGrepLogConsumer(t *testing.T) {
+	delegate := &testLogConsumer{}
+	consumer, err := GrepLogConsumer(delegate, "^ERROR|panic", false)
+	assert.NilError(t, err)
+	consumer.Log("c1", "s1", "INFO starting")
+	consumer.Log("c1", "s1", "ERROR failed to connect")
+	consumer.Log("c1", "s1", "goroutine panic: oops")
+	assert.DeepEqual(t, delegate.lines, []string{"ERROR failed to connect", "goroutine panic: oops"})
+
+	consumer, err = GrepLogConsumer(delegate, "", false)
+	assert.NilError(t, err)
+	assert.Equal(t, consumer, delegate)
+
+	_, err = GrepLogConsumer(delegate, "(", false)
+	assert.ErrorContains(t, err, `invalid grep pattern "("`)
+}
+
+func TestGrepLogConsumerTimestamps(t *testing.T) {
+	delegate := &testLogConsumer{}
+	consumer, err := GrepLogConsumer(delegate, "^ERROR", true)
+	assert.NilError(t, err)
+	consumer.Log("c1", "s1", "2021-06-10T10:30:00.000000000Z INFO starting")
+	consumer.Log("c1", "s1", "2021-06-10T10:30:01.000000000Z ERROR failed to connect")
+	consumer.Log("c1", "s1", "ERROR without timestamp")
+	assert.DeepEqual(t, delegate.lines, []string{"2021-06-10T10:30:01.000000000Z ERROR failed to connect", "ERROR without timestamp"})
+}
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	ts, err := ParseLogTime("", now)
+	assert.NilError(t, err)
+	assert.Assert(t, ts.IsZero())
+
+	ts, err = ParseLogTime("42m", now)
+	assert.NilError(t, err)
+	assert.Assert(t, ts.Equal(now.Add(-42*time.Minute)))
+
+	ts, err = ParseLogTime("2021-06-10T10:30:00Z", now)
+	assert.NilError(t, err)
+	assert.Assert(t, ts.Equal(time.Date(2021, 6, 10, 10, 30, 0, 0, time.UTC)))
+
+	_, err = ParseLogTime("yesterday", now)
+	assert.Assert(t, err != nil)
+}